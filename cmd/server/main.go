@@ -1,16 +1,93 @@
 package main
 
 import (
+	config_adapter "NameMatching/internal/adapters/config"
 	http_adapter "NameMatching/internal/adapters/http"
 	"NameMatching/internal/app"
+	"NameMatching/internal/domain"
+	"flag"
 	"github.com/gorilla/mux"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
 )
 
 func main() {
+	scoringConfigPath := flag.String("scoring-config", "", "path to a YAML or JSON scoring config file")
+	logLevel := flag.String("log-level", "info", "log level: debug, info, warn or error")
+	nicknamesPath := flag.String("nicknames", "", "path to a CSV file of extra nickname groups, added to the bundled dictionary")
+	tokenFrequenciesPath := flag.String("token-frequencies", "", "path to a census-style CSV file of name frequencies that weighs rare names more")
+	nameCorpusPath := flag.String("name-corpus", "", "path to a file of customer names, one per line, to learn name frequencies from")
+	disposableDomainsPath := flag.String("disposable-domains", "", "path to a file of extra disposable email domains, one per line, added to the bundled list")
+	traceMatching := flag.Bool("trace-matching", false, "log every name comparison step at debug level (includes customer names)")
+	flag.Parse()
+
+	// Set up the structured logger
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		log.Fatalf("Invalid log level: %v", err)
+	}
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	// Load the scoring config, falling back to the defaults
+	scoringConfig := domain.DefaultScoringConfig()
+	if *scoringConfigPath != "" {
+		var err error
+		scoringConfig, err = config_adapter.LoadScoringConfig(*scoringConfigPath)
+		if err != nil {
+			log.Fatalf("Failed to load scoring config: %v", err)
+		}
+	}
+
+	// Extend the bundled nickname dictionary with custom groups
+	nicknames := domain.DefaultNicknameDictionary()
+	if *nicknamesPath != "" {
+		groups, err := config_adapter.LoadNicknameGroups(*nicknamesPath)
+		if err != nil {
+			log.Fatalf("Failed to load nickname dictionary: %v", err)
+		}
+		nicknames = nicknames.Extend(groups)
+	}
+
+	// Weigh name tokens by how common they are, from census counts or our own customers
+	var frequencies *domain.TokenFrequencies
+	switch {
+	case *tokenFrequenciesPath != "" && *nameCorpusPath != "":
+		log.Fatalf("Only one of -token-frequencies and -name-corpus may be set")
+	case *tokenFrequenciesPath != "":
+		counts, err := config_adapter.LoadTokenFrequencies(*tokenFrequenciesPath)
+		if err != nil {
+			log.Fatalf("Failed to load token frequencies: %v", err)
+		}
+		frequencies = domain.NewTokenFrequencies(counts)
+	case *nameCorpusPath != "":
+		names, err := config_adapter.LoadNameCorpus(*nameCorpusPath)
+		if err != nil {
+			log.Fatalf("Failed to load name corpus: %v", err)
+		}
+		frequencies = domain.LearnTokenFrequencies(names)
+	}
+
+	// Extend the bundled disposable email domains with an up-to-date list
+	disposableDomains := domain.DefaultDisposableDomains()
+	if *disposableDomainsPath != "" {
+		domains, err := config_adapter.LoadDisposableDomains(*disposableDomainsPath)
+		if err != nil {
+			log.Fatalf("Failed to load disposable domains: %v", err)
+		}
+		disposableDomains = disposableDomains.Extend(domains)
+	}
+
 	// Initialize services
-	riskService := &app.CustomerValidationService{}
+	nameMatcher := domain.NewNameMatcher(scoringConfig,
+		domain.WithLogger(logger),
+		domain.WithDebugTrace(*traceMatching),
+		domain.WithNicknameDictionary(nicknames),
+		domain.WithTokenFrequencies(frequencies),
+		domain.WithDisposableDomains(disposableDomains),
+	)
+	riskService := app.NewCustomerValidationService(nameMatcher)
 
 	// Initialize adapters
 	httpAdapter := http_adapter.NewHTTPAdapter(riskService)
@@ -18,7 +95,10 @@ func main() {
 	// Set up routes
 	router := mux.NewRouter()
 	router.HandleFunc("/name-match", httpAdapter.NameMatchHandler).Methods("POST")
+	router.HandleFunc("/organization-match", httpAdapter.OrganizationMatchHandler).Methods("POST")
 	router.HandleFunc("/email-match", httpAdapter.EmailMatchHandler).Methods("POST")
+	router.HandleFunc("/name-email-match", httpAdapter.NameEmailMatchHandler).Methods("POST")
+	router.HandleFunc("/customer-match", httpAdapter.CustomerMatchHandler).Methods("POST")
 
 	// Start the HTTP server
 	log.Println("Starting server on port 8080...")
@@ -8,5 +8,8 @@ require github.com/dlclark/metaphone3 v0.0.0-20190903202417-5fe87fcdd547
 
 require (
 	github.com/agnivade/levenshtein v1.2.0
+	golang.org/x/net v0.30.0
 	golang.org/x/text v0.19.0
 )
+
+require gopkg.in/yaml.v3 v3.0.1
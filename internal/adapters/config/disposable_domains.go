@@ -0,0 +1,41 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadDisposableDomains reads disposable email domains from a text file (see ParseDisposableDomains)
+func LoadDisposableDomains(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading disposable domains: %w", err)
+	}
+	defer file.Close()
+	return ParseDisposableDomains(file)
+}
+
+// ParseDisposableDomains decodes disposable email domains, one per line, the format of the lists
+// maintained by the community ("mailinator.com"). Blank lines and lines starting with # are
+// skipped; a domain with spaces or an "@" is an error.
+func ParseDisposableDomains(r io.Reader) ([]string, error) {
+	var domains []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		domain := strings.TrimSpace(scanner.Text())
+		if domain == "" || strings.HasPrefix(domain, "#") {
+			continue
+		}
+		if strings.ContainsAny(domain, " \t@") {
+			return nil, fmt.Errorf("disposable domains line %d: invalid domain %q", line, domain)
+		}
+		domains = append(domains, domain)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading disposable domains: %w", err)
+	}
+	return domains, nil
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDisposableDomains(t *testing.T) {
+	data := "# disposable email blocklist\nmailinator.com\n\n  yopmail.fr \n"
+	domains, err := ParseDisposableDomains(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(domains) != 2 || domains[0] != "mailinator.com" || domains[1] != "yopmail.fr" {
+		t.Errorf("Expected two domains, got %v", domains)
+	}
+}
+
+func TestParseDisposableDomainsRejectsAddresses(t *testing.T) {
+	if _, err := ParseDisposableDomains(strings.NewReader("john@mailinator.com\n")); err == nil {
+		t.Errorf("Expected an error for an address instead of a domain")
+	}
+}
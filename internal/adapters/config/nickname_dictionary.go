@@ -0,0 +1,53 @@
+package config
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadNicknameGroups reads groups of equivalent given names from a CSV file (see ParseNicknameGroups)
+func LoadNicknameGroups(path string) ([][]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading nickname dictionary: %w", err)
+	}
+	defer file.Close()
+	return ParseNicknameGroups(file)
+}
+
+// ParseNicknameGroups decodes groups of equivalent given names from CSV. Every record is one group,
+// usually the formal name followed by its variants ("william,bill,will,liam"); records may have
+// any number of fields and lines starting with # are comments.
+func ParseNicknameGroups(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var groups [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding nickname dictionary: %w", err)
+		}
+
+		var group []string
+		for _, name := range record {
+			if name = strings.TrimSpace(name); name != "" {
+				group = append(group, name)
+			}
+		}
+		if len(group) < 2 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("nickname dictionary line %d: a group needs at least two names", line)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
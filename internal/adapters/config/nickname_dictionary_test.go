@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNicknameGroups(t *testing.T) {
+	data := "# formal name, variants\nbartholomew, bart, bat\n\njürgen,jupp\n"
+	groups, err := ParseNicknameGroups(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(groups) != 2 || len(groups[0]) != 3 || groups[0][1] != "bart" || groups[1][0] != "jürgen" {
+		t.Errorf("Expected two groups, got %v", groups)
+	}
+}
+
+func TestParseNicknameGroupsRejectsSingleNames(t *testing.T) {
+	if _, err := ParseNicknameGroups(strings.NewReader("william,bill\nrobert\n")); err == nil {
+		t.Errorf("Expected an error for a group with a single name")
+	}
+}
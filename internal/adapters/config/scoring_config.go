@@ -0,0 +1,63 @@
+package config
+
+import (
+	"NameMatching/internal/domain"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// removedScoringKeys are keys older scoring configs may carry that no longer have an effect, with
+// the reason. A config setting one is rejected rather than silently scored differently.
+var removedScoringKeys = map[string]string{
+	"token_weight": "token scores are no longer scaled since name scores are bounded to [0,1]; " +
+		"weigh name components with first_name_weight, last_name_weight and middle_name_weight",
+	"email_domain_similarity":  "email domains are only read as typos of popular providers (see domain.SuggestEmailDomain)",
+	"email_domain_typo_cutoff": "email domains are only read as typos of popular providers (see domain.SuggestEmailDomain)",
+}
+
+// LoadScoringConfig reads a scoring config from a YAML (.yaml, .yml) or JSON (.json) file.
+// Fields missing from the file keep their domain.DefaultScoringConfig values.
+func LoadScoringConfig(path string) (domain.ScoringConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.ScoringConfig{}, fmt.Errorf("reading scoring config: %w", err)
+	}
+	return ParseScoringConfig(data, filepath.Ext(path))
+}
+
+// ParseScoringConfig decodes a scoring config in the format named by the file extension ext
+func ParseScoringConfig(data []byte, ext string) (domain.ScoringConfig, error) {
+	config := domain.DefaultScoringConfig()
+
+	var keys map[string]any
+	var err error
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		if err = yaml.Unmarshal(data, &keys); err == nil {
+			err = yaml.Unmarshal(data, &config)
+		}
+	case ".json":
+		if err = json.Unmarshal(data, &keys); err == nil {
+			err = json.Unmarshal(data, &config)
+		}
+	default:
+		return domain.ScoringConfig{}, fmt.Errorf("unsupported scoring config format %q", ext)
+	}
+	if err != nil {
+		return domain.ScoringConfig{}, fmt.Errorf("decoding scoring config: %w", err)
+	}
+	for key := range keys {
+		if reason, ok := removedScoringKeys[key]; ok {
+			return domain.ScoringConfig{}, fmt.Errorf("invalid scoring config: %s was removed: %s", key, reason)
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return domain.ScoringConfig{}, fmt.Errorf("invalid scoring config: %w", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"NameMatching/internal/domain"
+	"strings"
+	"testing"
+)
+
+func TestParseScoringConfigYAML(t *testing.T) {
+	config, err := ParseScoringConfig([]byte("phonetic_boost: 0.85\nemail_weight: 0.25\n"), ".yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.PhoneticBoost != 0.85 || config.EmailWeight != 0.25 {
+		t.Errorf("Expected phonetic_boost 0.85 and email_weight 0.25, got %+v", config)
+	}
+	if config.LevenshteinCutoff != domain.DefaultScoringConfig().LevenshteinCutoff {
+		t.Errorf("Expected missing fields to keep their defaults, got levenshtein_cutoff %.2f", config.LevenshteinCutoff)
+	}
+}
+
+func TestParseScoringConfigJSON(t *testing.T) {
+	config, err := ParseScoringConfig([]byte(`{"levenshtein_cutoff": 0.7, "name_weight": 0.8}`), ".json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.LevenshteinCutoff != 0.7 || config.NameWeight != 0.8 {
+		t.Errorf("Expected levenshtein_cutoff 0.7 and name_weight 0.8, got %+v", config)
+	}
+}
+
+func TestParseScoringConfigRejectsInvalidValues(t *testing.T) {
+	if _, err := ParseScoringConfig([]byte("levenshtein_cutoff: 1.5\n"), ".yml"); err == nil {
+		t.Errorf("Expected an error for a levenshtein_cutoff above 1")
+	}
+	if _, err := ParseScoringConfig([]byte("name_weight: 0\nemail_weight: 0\n"), ".yaml"); err == nil {
+		t.Errorf("Expected an error when name and email weights are both zero")
+	}
+	if _, err := ParseScoringConfig([]byte("phonetic_boost = 0.9"), ".toml"); err == nil {
+		t.Errorf("Expected an error for an unsupported format")
+	}
+}
+
+func TestParseScoringConfigRejectsRemovedKeys(t *testing.T) {
+	for ext, data := range map[string]string{
+		".yaml": "token_weight: 0.4\n",
+		".json": `{"token_weight": 0.4}`,
+	} {
+		_, err := ParseScoringConfig([]byte(data), ext)
+		if err == nil || !strings.Contains(err.Error(), "token_weight was removed") {
+			t.Errorf("Expected %s config with token_weight to be rejected, got %v", ext, err)
+		}
+	}
+	if _, err := ParseScoringConfig([]byte("email_domain_typo_cutoff: 0.85\n"), ".yaml"); err == nil {
+		t.Errorf("Expected a config with email_domain_typo_cutoff to be rejected")
+	}
+}
+
+func TestParseScoringConfigReportsTheSameInvalidWeightEveryTime(t *testing.T) {
+	data := []byte("phone_weight: -1\naddress_weight: -1\nemail_weight: -1\n")
+	for i := 0; i < 20; i++ {
+		_, err := ParseScoringConfig(data, ".yaml")
+		if err == nil || !strings.Contains(err.Error(), "address_weight must not be negative") {
+			t.Fatalf("Expected address_weight to be reported first, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LoadTokenFrequencies reads name frequencies from a CSV file (see ParseTokenFrequencies)
+func LoadTokenFrequencies(path string) (map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading token frequencies: %w", err)
+	}
+	defer file.Close()
+	return ParseTokenFrequencies(file)
+}
+
+// ParseTokenFrequencies decodes name frequencies from census-style CSV: every record is a name
+// followed by the number of people carrying it ("smith,2442977"). Extra columns are ignored, a
+// header whose count is not a number is skipped and lines starting with # are comments.
+func ParseTokenFrequencies(r io.Reader) (map[string]int, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	counts := map[string]int{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding token frequencies: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(record) < 2 {
+			return nil, fmt.Errorf("token frequencies line %d: expected a name and a count", line)
+		}
+		name := strings.TrimSpace(record[0])
+		count, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			if first {
+				continue
+			}
+			return nil, fmt.Errorf("token frequencies line %d: invalid count %q", line, record[1])
+		}
+		if name == "" || count < 0 {
+			return nil, fmt.Errorf("token frequencies line %d: expected a name and a non-negative count", line)
+		}
+		counts[name] += count
+	}
+	return counts, nil
+}
+
+// LoadNameCorpus reads a corpus of full names, one per line, to learn token frequencies from.
+// Blank lines and lines starting with # are skipped.
+func LoadNameCorpus(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading name corpus: %w", err)
+	}
+	defer file.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if name := strings.TrimSpace(scanner.Text()); name != "" && !strings.HasPrefix(name, "#") {
+			names = append(names, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading name corpus: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTokenFrequencies(t *testing.T) {
+	data := "name,count,rank\n# US census 2010\nsmith, 2442977, 1\nGARCIA,1166120,6\n"
+	counts, err := ParseTokenFrequencies(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(counts) != 2 || counts["smith"] != 2442977 || counts["GARCIA"] != 1166120 {
+		t.Errorf("Expected two counts, got %v", counts)
+	}
+}
+
+func TestParseTokenFrequenciesRejectsInvalidCounts(t *testing.T) {
+	for _, data := range []string{"smith,100\ngarcia,many\n", "smith\n", "smith,-1\n"} {
+		if _, err := ParseTokenFrequencies(strings.NewReader(data)); err == nil {
+			t.Errorf("Expected an error for %q", data)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"NameMatching/internal/app"
+	"NameMatching/internal/domain"
 	"encoding/json"
 	"net/http"
 )
@@ -20,11 +21,75 @@ func (h *HTTPAdapter) NameMatchHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name1 string `json:"name1"`
 		Name2 string `json:"name2"`
+		// Culture optionally names the culture of both names ("hu", "ja", "es")
+		Culture string `json:"culture"`
+		// Channel optionally names the input channel of the names ("keyboard", "document-scan")
+		Channel string `json:"channel"`
 	}
 	_ = json.NewDecoder(r.Body).Decode(&req)
 
-	_, score := h.customerValidationService.ValidateCustomer(req.Name1, req.Name2, "", "", 0.8)
-	err := json.NewEncoder(w).Encode(map[string]float64{"score": score})
+	opts := domain.CompareOptionsForCulture(req.Culture)
+	opts.Channel = req.Channel
+	details := h.customerValidationService.ExplainNameMatchWithOptions(req.Name1, req.Name2, opts)
+	err := json.NewEncoder(w).Encode(struct {
+		Score   float64                `json:"score"`
+		Details domain.NameMatchResult `json:"details"`
+	}{Score: details.Score, Details: details})
+	if err != nil {
+		return
+	}
+}
+
+// OrganizationMatchHandler handles organization name matching API requests
+func (h *HTTPAdapter) OrganizationMatchHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name1 string `json:"name1"`
+		Name2 string `json:"name2"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	details := h.customerValidationService.MatchOrganizations(req.Name1, req.Name2)
+	err := json.NewEncoder(w).Encode(struct {
+		Score   float64                        `json:"score"`
+		Details domain.OrganizationMatchResult `json:"details"`
+	}{Score: details.Score, Details: details})
+	if err != nil {
+		return
+	}
+}
+
+// NameEmailMatchHandler handles name-to-email consistency API requests
+func (h *HTTPAdapter) NameEmailMatchHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	details := h.customerValidationService.CheckNameEmailConsistency(req.Name, req.Email)
+	err := json.NewEncoder(w).Encode(struct {
+		Score   float64                `json:"score"`
+		Details domain.NameEmailResult `json:"details"`
+	}{Score: details.Score, Details: details})
+	if err != nil {
+		return
+	}
+}
+
+// CustomerMatchHandler handles customer record matching API requests, comparing every attribute
+// both records carry
+func (h *HTTPAdapter) CustomerMatchHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Customer1 domain.Customer `json:"customer1"`
+		Customer2 domain.Customer `json:"customer2"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	details := h.customerValidationService.ExplainCustomerMatch(&req.Customer1, &req.Customer2)
+	err := json.NewEncoder(w).Encode(struct {
+		Score   float64                    `json:"score"`
+		Details domain.CustomerMatchResult `json:"details"`
+	}{Score: details.Score, Details: details})
 	if err != nil {
 		return
 	}
@@ -38,8 +103,18 @@ func (h *HTTPAdapter) EmailMatchHandler(w http.ResponseWriter, r *http.Request)
 	}
 	_ = json.NewDecoder(r.Body).Decode(&req)
 
-	_, score := h.customerValidationService.ValidateCustomer("", "", req.Email1, req.Email2, 0.8)
-	err := json.NewEncoder(w).Encode(map[string]float64{"score": score})
+	details := h.customerValidationService.ExplainEmailMatch(req.Email1, req.Email2)
+	// TrustedScore is the score as evidence of identity: 0 when either address is disposable, a
+	// role account or invalid, as in customer comparisons with nothing else to compare
+	trustedScore := 0.0
+	if details.Trusted() {
+		trustedScore = details.Score
+	}
+	err := json.NewEncoder(w).Encode(struct {
+		Score        float64                 `json:"score"`
+		TrustedScore float64                 `json:"trusted_score"`
+		Details      domain.EmailMatchResult `json:"details"`
+	}{Score: details.Score, TrustedScore: trustedScore, Details: details})
 	if err != nil {
 		return
 	}
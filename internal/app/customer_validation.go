@@ -3,21 +3,84 @@ package app
 import "NameMatching/internal/domain"
 
 // CustomerValidationService orchestrates customer validation (use case)
-type CustomerValidationService struct{}
+type CustomerValidationService struct {
+	matcher *domain.NameMatcher
+}
+
+// NewCustomerValidationService creates a CustomerValidationService that compares names with the given matcher.
+// The zero value CustomerValidationService uses a matcher with domain.DefaultScoringConfig.
+func NewCustomerValidationService(matcher *domain.NameMatcher) *CustomerValidationService {
+	return &CustomerValidationService{matcher: matcher}
+}
+
+// NameMatcher returns the name matcher used by the service
+func (s *CustomerValidationService) NameMatcher() *domain.NameMatcher {
+	if s.matcher == nil {
+		return domain.DefaultNameMatcher()
+	}
+	return s.matcher
+}
+
+// ScoringConfig returns the scoring config used by the service
+func (s *CustomerValidationService) ScoringConfig() domain.ScoringConfig {
+	return s.NameMatcher().Config()
+}
 
 // ValidateCustomer orchestrates the validation of two customers' names and emails
 func (s *CustomerValidationService) ValidateCustomer(name1, name2, email1, email2 string, threshold float64) (bool, float64) {
-	// Create customer domain objects
-	customer1 := domain.NewCustomer(name1, email1)
-	customer2 := domain.NewCustomer(name2, email2)
-
-	// Perform name and email matching using domain logic
-	nameScore := customer1.MatchName(customer2.Name)
-	emailScore := customer1.MatchEmail(customer2.Email)
+	return s.ValidateCustomers(domain.NewCustomer(name1, email1), domain.NewCustomer(name2, email2), threshold)
+}
 
-	// Combine the scores (you can refine this logic as per your business need)
-	finalScore := (nameScore + emailScore) / 2
+// ValidateCustomers orchestrates the validation of two customer records, combining the scores of
+// every attribute both records carry
+func (s *CustomerValidationService) ValidateCustomers(customer1, customer2 *domain.Customer, threshold float64) (bool, float64) {
+	result := customer1.MatchWith(s.NameMatcher(), customer2)
 
 	// Apply the threshold check
-	return domain.IsMatch(finalScore, threshold), finalScore
+	return domain.IsMatch(result.Score, threshold), result.Score
+}
+
+// ExplainCustomerMatch returns the per-attribute score breakdown for two customer records
+func (s *CustomerValidationService) ExplainCustomerMatch(customer1, customer2 *domain.Customer) domain.CustomerMatchResult {
+	return s.NameMatcher().CompareCustomers(customer1, customer2)
+}
+
+// ExplainDateOfBirthMatch returns the score of two dates of birth and the data-entry error that
+// tells them apart, if any (see domain.DateOfBirthComparator)
+func (s *CustomerValidationService) ExplainDateOfBirthMatch(date1, date2 string) domain.FieldMatch {
+	return s.NameMatcher().CompareField(domain.FieldDateOfBirth, date1, date2)
+}
+
+// ExplainNameMatch returns the detailed score breakdown for two names
+func (s *CustomerValidationService) ExplainNameMatch(name1, name2 string) domain.NameMatchResult {
+	return s.NameMatcher().Compare(name1, name2)
+}
+
+// ExplainNameMatchForCulture returns the detailed score breakdown for two names of a culture
+// ("hu", "ja-JP", "es-CO"), which sets the expected name order and the Spanish double-surname
+// rules; an empty culture lets the matcher decide
+func (s *CustomerValidationService) ExplainNameMatchForCulture(name1, name2, culture string) domain.NameMatchResult {
+	return s.NameMatcher().CompareWithOptions(name1, name2, domain.CompareOptionsForCulture(culture))
+}
+
+// ExplainNameMatchWithOptions returns the detailed score breakdown for two names compared with
+// per-request hints such as their culture and input channel
+func (s *CustomerValidationService) ExplainNameMatchWithOptions(name1, name2 string, opts domain.CompareOptions) domain.NameMatchResult {
+	return s.NameMatcher().CompareWithOptions(name1, name2, opts)
+}
+
+// ExplainEmailMatch returns the detailed score breakdown for two email addresses
+func (s *CustomerValidationService) ExplainEmailMatch(email1, email2 string) domain.EmailMatchResult {
+	return s.NameMatcher().CompareEmails(email1, email2)
+}
+
+// CheckNameEmailConsistency scores how plausibly an email address belongs to a person of the given
+// name, with no second record to compare against
+func (s *CustomerValidationService) CheckNameEmailConsistency(name, email string) domain.NameEmailResult {
+	return s.NameMatcher().CompareNameWithEmail(name, email)
+}
+
+// MatchOrganizations returns the detailed score breakdown for two organization names
+func (s *CustomerValidationService) MatchOrganizations(name1, name2 string) domain.OrganizationMatchResult {
+	return s.NameMatcher().CompareOrganizations(name1, name2)
 }
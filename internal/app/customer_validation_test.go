@@ -1,6 +1,7 @@
 package app
 
 import (
+	"NameMatching/internal/domain"
 	"testing"
 )
 
@@ -39,3 +40,74 @@ func TestCustomerValidationPreventPartialMatchBryanBrianne(t *testing.T) {
 		t.Errorf("Expected no match for 'Bryan' and 'Brianne'")
 	}
 }
+
+func TestCustomerValidationUsesScoringConfig(t *testing.T) {
+	config := domain.DefaultScoringConfig()
+	config.NameWeight = 1.0
+	config.EmailWeight = 0.0
+	service := NewCustomerValidationService(domain.NewNameMatcher(config))
+	_, score := service.ValidateCustomer("John Doe", "John Doe", "john@example.com", "someone.else@example.org", 0.8)
+
+	if score != 1.0 {
+		t.Errorf("Expected email to be ignored with email_weight 0, got score %.2f", score)
+	}
+}
+
+func TestMatchOrganizations(t *testing.T) {
+	service := CustomerValidationService{}
+	result := service.MatchOrganizations("Acme Corp.", "ACME Corporation Ltd")
+
+	if result.Score != 1.0 || result.Rule != domain.RuleOrganizationExact {
+		t.Errorf("Expected 'Acme Corp.' and 'ACME Corporation Ltd' to match exactly, got %.2f (%s)", result.Score, result.Rule)
+	}
+}
+
+func TestCustomerValidationCanonicalEmailMatch(t *testing.T) {
+	service := CustomerValidationService{}
+	match, score := service.ValidateCustomer("John Doe", "John Doe", "j.o.h.n+promo@gmail.com", "john@googlemail.com", 0.8)
+
+	if !match || score != 1.0 {
+		t.Errorf("Expected the same Gmail mailbox to match with score 1.0, got %.2f", score)
+	}
+}
+
+func TestCheckNameEmailConsistency(t *testing.T) {
+	service := CustomerValidationService{}
+	result := service.CheckNameEmailConsistency("Brayan Perez", "brayan.perez87@gmail.com")
+
+	if result.Score != 1.0 || result.Pattern != domain.PatternFirstLast {
+		t.Errorf("Expected 'brayan.perez87' to derive from 'Brayan Perez', got %.2f (%s)", result.Score, result.Pattern)
+	}
+}
+
+func TestCustomerValidationIgnoresDisposableEmailMatch(t *testing.T) {
+	service := CustomerValidationService{}
+	nameScore := service.ExplainNameMatch("John Smith", "Jon Smyth").Score
+	_, score := service.ValidateCustomer("John Smith", "Jon Smyth", "test@mailinator.com", "test@mailinator.com", 0.8)
+
+	if score > nameScore {
+		t.Errorf("Expected a shared mailinator address not to raise the name score %.2f, got %.2f", nameScore, score)
+	}
+}
+
+func TestValidateCustomersUsesEveryPresentField(t *testing.T) {
+	service := CustomerValidationService{}
+	customer1 := &domain.Customer{Name: "Brayan Perez", Email: "brayan@example.com", DateOfBirth: "1990-05-17", Phone: "+57 300 123 4567"}
+	customer2 := &domain.Customer{Name: "Brayan Perez", Email: "brayan@example.com", DateOfBirth: "1988-02-01", Phone: "+57 311 765 4321"}
+	match, score := service.ValidateCustomers(customer1, customer2, 0.8)
+
+	if match {
+		t.Errorf("Expected a different date of birth and phone to prevent a match, got %.2f", score)
+	}
+}
+
+func TestExplainDateOfBirthMatch(t *testing.T) {
+	config := domain.DefaultScoringConfig()
+	config.DateOfBirthMonthFirst = true
+	service := NewCustomerValidationService(domain.NewNameMatcher(config))
+	match := service.ExplainDateOfBirthMatch("03/07/1985", "1985-07-03")
+
+	if match.Rule != domain.RuleDateOfBirthTransposed || match.Field != domain.FieldDateOfBirth {
+		t.Errorf("Expected a US and a European date to be transposed, got %.2f (%s)", match.Score, match.Rule)
+	}
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// BeiderMorse is a simplified, language-agnostic encoder in the style of Beider-Morse Phonetic
+// Matching. Instead of guessing the language of a name, it rewrites spellings that sound alike
+// across German, Spanish, Portuguese, Italian, Slavic and English names ("Jiménez"/"Ximenez",
+// "Müller"/"Mueller", "Schmidt"/"Smith", "Wagner"/"Vagner"). The primary key follows Germanic and
+// Spanish pronunciations and the alternate key English and Slavic ones. It is not the full BMPM
+// rule set, which distinguishes dozens of languages.
+type BeiderMorse struct{}
+
+// beiderMorseRule rewrites pattern when it is followed by one of the runes in followedBy
+// (any rune when followedBy is empty)
+type beiderMorseRule struct {
+	pattern    string
+	followedBy string
+	primary    string
+	alternate  string
+}
+
+// beiderMorseRules are tried in order at every position; longer patterns come first
+var beiderMorseRules = []beiderMorseRule{
+	{"tsch", "", "ch", "ch"},
+	{"sch", "", "s", "sk"},
+	{"tch", "", "ch", "ch"},
+	{"ch", "", "ch", "x"},
+	{"sh", "", "s", "s"},
+	{"sz", "", "s", "s"},
+	{"cz", "", "ch", "ch"},
+	{"ph", "", "f", "f"},
+	{"th", "", "t", "t"},
+	{"ck", "", "k", "k"},
+	{"dt", "", "t", "t"},
+	{"qu", "", "k", "kv"},
+	{"gu", "eiy", "g", "g"},
+	{"ll", "", "l", "i"},
+	{"nh", "", "n", "n"},
+	{"gn", "", "gn", "n"},
+	{"lh", "", "l", "l"},
+	{"ae", "", "a", "e"},
+	{"oe", "", "o", "e"},
+	{"ue", "", "u", "i"},
+	{"ei", "", "ai", "ai"},
+	{"ey", "", "ai", "ai"},
+	{"ai", "", "ai", "ai"},
+	{"ay", "", "ai", "ai"},
+	{"ou", "", "u", "u"},
+	{"c", "eiy", "s", "ts"},
+	{"g", "eiy", "x", "g"},
+	{"c", "", "k", "k"},
+	{"j", "", "x", "i"},
+	{"x", "", "x", "ks"},
+	{"z", "", "s", "ts"},
+	{"v", "", "v", "b"},
+	{"w", "", "v", "u"},
+	{"y", "", "i", "i"},
+	{"q", "", "k", "k"},
+	{"h", "", "", ""},
+	{"ß", "", "s", "s"},
+	{"ñ", "", "n", "n"},
+}
+
+// Encode returns the primary and alternate Beider-Morse style keys of name
+func (BeiderMorse) Encode(name string) (string, string) {
+	word := []rune(strings.ToLower(name))
+	var primary, alternate strings.Builder
+
+	for i := 0; i < len(word); {
+		if !unicode.IsLetter(word[i]) {
+			i++
+			continue
+		}
+		rule, ok := matchBeiderMorseRule(word, i)
+		if !ok {
+			primary.WriteRune(word[i])
+			alternate.WriteRune(word[i])
+			i++
+			continue
+		}
+		primary.WriteString(rule.primary)
+		alternate.WriteString(rule.alternate)
+		i += len([]rune(rule.pattern))
+	}
+
+	primaryKey, alternateKey := collapseRepeats(primary.String()), collapseRepeats(alternate.String())
+	if alternateKey == primaryKey {
+		alternateKey = ""
+	}
+	return primaryKey, alternateKey
+}
+
+// matchBeiderMorseRule returns the first rule matching word at position i
+func matchBeiderMorseRule(word []rune, i int) (beiderMorseRule, bool) {
+	for _, rule := range beiderMorseRules {
+		pattern := []rune(rule.pattern)
+		if i+len(pattern) > len(word) || string(word[i:i+len(pattern)]) != rule.pattern {
+			continue
+		}
+		if rule.followedBy != "" {
+			next := i + len(pattern)
+			if next >= len(word) || !strings.ContainsRune(rule.followedBy, word[next]) {
+				continue
+			}
+		}
+		return rule, true
+	}
+	return beiderMorseRule{}, false
+}
+
+// collapseRepeats replaces runs of the same rune with a single rune
+func collapseRepeats(s string) string {
+	var sb strings.Builder
+	var last rune
+	for i, r := range []rune(s) {
+		if i > 0 && r == last {
+			continue
+		}
+		sb.WriteRune(r)
+		last = r
+	}
+	return sb.String()
+}
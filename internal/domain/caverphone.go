@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Caverphone2 is David Hood's Caverphone 2.0 encoder, designed for New Zealand electoral rolls.
+// Keys are always ten characters long.
+type Caverphone2 struct{}
+
+// caverphone2Rules are the ordered rewrite rules of Caverphone 2.0
+var caverphone2Rules = compileRewriteRules([][2]string{
+	{`e$`, ``},
+	{`^cough`, `cou2f`}, {`^rough`, `rou2f`}, {`^tough`, `tou2f`}, {`^enough`, `enou2f`}, {`^trough`, `trou2f`},
+	{`^gn`, `2n`}, {`mb$`, `m2`},
+	{`cq`, `2q`}, {`ci`, `si`}, {`ce`, `se`}, {`cy`, `sy`}, {`tch`, `2ch`},
+	{`c`, `k`}, {`q`, `k`}, {`x`, `k`}, {`v`, `f`}, {`dg`, `2g`},
+	{`tio`, `sio`}, {`tia`, `sia`}, {`d`, `t`}, {`ph`, `fh`}, {`b`, `p`}, {`sh`, `s2`}, {`z`, `s`},
+	{`^[aeiou]`, `A`}, {`[aeiou]`, `3`},
+	{`j`, `y`}, {`^y3`, `Y3`}, {`^y`, `A`}, {`y`, `3`},
+	{`3gh3`, `3kh3`}, {`gh`, `22`}, {`g`, `k`},
+	{`s+`, `S`}, {`t+`, `T`}, {`p+`, `P`}, {`k+`, `K`}, {`f+`, `F`}, {`m+`, `M`}, {`n+`, `N`},
+	{`w3`, `W3`}, {`wh3`, `Wh3`}, {`w$`, `3`}, {`w`, `2`},
+	{`^h`, `A`}, {`h`, `2`},
+	{`r3`, `R3`}, {`r$`, `3`}, {`r`, `2`},
+	{`l3`, `L3`}, {`l$`, `3`}, {`l`, `2`},
+	{`2`, ``}, {`3$`, `A`}, {`3`, ``},
+})
+
+// Encode returns the Caverphone 2.0 key of name
+func (Caverphone2) Encode(name string) (string, string) {
+	word := strings.ToLower(string(asciiLetters(name)))
+	if word == "" {
+		return "", ""
+	}
+	for _, rule := range caverphone2Rules {
+		word = rule.pattern.ReplaceAllString(word, rule.replacement)
+	}
+	return (word + "1111111111")[:10], ""
+}
+
+// rewriteRule replaces every match of pattern with replacement
+type rewriteRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// compileRewriteRules compiles pattern/replacement pairs into rewrite rules
+func compileRewriteRules(rules [][2]string) []rewriteRule {
+	compiled := make([]rewriteRule, len(rules))
+	for i, rule := range rules {
+		compiled[i] = rewriteRule{pattern: regexp.MustCompile(rule[0]), replacement: rule[1]}
+	}
+	return compiled
+}
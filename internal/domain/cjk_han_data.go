@@ -0,0 +1,115 @@
+package domain
+
+// hanPinyinGroups lists, for each toneless Hanyu Pinyin syllable, the simplified and traditional
+// characters common in Chinese surnames and given names that are read with it
+var hanPinyinGroups = map[string]string{
+	"a": "阿", "ai": "艾爱愛", "an": "安", "ang": "昂", "ao": "敖奥奧",
+	"ba": "巴", "bai": "白柏百", "ban": "班", "bao": "包宝寶保鲍鮑", "bei": "贝貝北",
+	"ben": "本", "bi": "毕畢碧", "bian": "卞边邊", "bin": "斌彬宾賓滨濱", "bing": "冰兵炳秉",
+	"bo": "博波伯", "bu": "步卜",
+	"cai": "蔡才财財彩", "cao": "曹草", "cen": "岑", "chang": "常昌畅暢长長", "chao": "超朝巢",
+	"chen": "陈陳晨辰臣琛", "cheng": "程成诚誠承城澄", "chi": "池迟遲", "chong": "崇",
+	"chu": "楚储儲初", "chun": "春纯純淳", "cong": "丛叢聪聰", "cui": "崔翠",
+	"da": "达達大", "dai": "戴岱黛", "dan": "丹旦", "dang": "党黨", "de": "德", "deng": "邓鄧登",
+	"di": "狄迪笛", "dian": "典", "ding": "丁鼎", "dong": "董东東冬栋棟", "du": "杜都", "duan": "段端",
+	"en": "恩",
+	"fa": "发發法", "fan": "范樊凡帆繁", "fang": "方芳房", "fei": "费費飞飛菲", "fen": "芬",
+	"feng": "冯馮峰锋鋒丰豐凤鳳枫楓", "fu": "傅付福富甫符扶",
+	"gang": "刚剛钢鋼", "gao": "高", "ge": "葛戈格歌", "geng": "耿", "gong": "龚龔宫宮巩鞏公",
+	"gu": "顾顧谷古", "guan": "关關管冠官", "guang": "光广廣", "gui": "桂贵貴", "guo": "郭国國果",
+	"hai": "海", "han": "韩韓汉漢寒涵含翰", "hang": "杭航", "hao": "郝浩昊豪好皓",
+	"he": "何贺賀和河荷鹤鶴", "hong": "洪红紅宏弘鸿鴻虹", "hou": "侯", "hu": "胡虎湖狐",
+	"hua": "华華花", "huai": "怀懷", "huan": "欢歡环環焕煥", "huang": "黄黃皇", "hui": "惠辉輝慧晖暉",
+	"huo": "霍火",
+	"ji":  "纪紀季姬吉计計济濟", "jia": "贾賈家佳嘉甲", "jian": "简簡建健剑劍坚堅",
+	"jiang": "江姜蒋蔣将將疆", "jiao": "焦娇嬌", "jie": "杰傑洁潔捷节節", "jin": "金晋晉锦錦进進近津瑾",
+	"jing": "景静靜晶京敬婧菁", "jiu": "久", "ju": "居菊鞠", "juan": "娟", "jun": "军軍俊君钧鈞骏駿",
+	"kai": "凯凱开開楷", "kang": "康", "ke": "柯可克科", "kong": "孔空", "kun": "坤昆",
+	"lai": "赖賴来來", "lan": "兰蘭蓝藍岚嵐", "lang": "郎朗", "le": "乐樂", "lei": "雷磊蕾",
+	"li": "李黎丽麗利力立礼禮莉理励勵", "lian": "连連廉莲蓮", "liang": "梁良亮", "liao": "廖",
+	"lin": "林琳霖临臨麟", "ling": "凌玲灵靈令", "liu": "刘劉柳流", "long": "龙龍隆",
+	"lou": "楼樓娄婁", "lu": "卢盧陆陸鲁魯路露璐吕呂鹿", "luo": "罗羅骆駱洛",
+	"ma": "马馬", "mai": "麦麥", "man": "曼满滿", "mao": "毛茂", "mei": "梅美媚", "meng": "孟蒙梦夢萌",
+	"mi": "米", "miao": "苗妙", "min": "敏民闵閔", "ming": "明铭銘鸣鳴", "mo": "莫墨默",
+	"mu": "穆木慕牧",
+	"na": "娜纳納", "nan": "南楠男", "ni": "倪妮", "ning": "宁寧凝", "niu": "牛",
+	"ou":  "欧歐",
+	"pan": "潘盼", "pei": "裴佩培", "peng": "彭鹏鵬朋", "ping": "平萍",
+	"qi": "齐齊祁琪琦奇启啟", "qian": "钱錢倩千谦謙", "qiang": "强強", "qiao": "乔喬",
+	"qin": "秦琴勤钦欽覃", "qing": "清青庆慶晴", "qiu": "邱丘秋", "qu": "曲屈", "quan": "全泉权權",
+	"ran": "冉然", "ren": "任仁人", "rong": "荣榮容蓉融", "ru": "如汝", "rui": "瑞睿锐銳", "ruo": "若",
+	"shan": "山珊单單善", "shang": "尚商上", "shao": "邵少绍紹", "shen": "沈申深神",
+	"sheng": "盛胜勝生圣聖", "shi": "石史施师師诗詩世士时時", "shu": "舒叔淑书書",
+	"shuang": "双雙爽", "shui": "水", "shun": "顺順舜", "si": "思斯司", "song": "宋松颂頌",
+	"su": "苏蘇素", "sun": "孙孫",
+	"tai": "太泰", "tan": "谭譚谈談坦", "tang": "唐汤湯棠", "tao": "陶涛濤桃韬韜", "tian": "田天甜",
+	"ting": "婷庭亭廷", "tong": "童佟通彤", "tu": "徒",
+	"wan": "万萬婉宛", "wang": "王汪旺", "wei": "魏韦韋卫衛伟偉薇维維威巍尉", "wen": "温溫文闻聞雯",
+	"wu": "吴吳武伍吾午",
+	"xi": "习習席西熙希曦喜", "xia": "夏霞", "xian": "贤賢仙先娴嫻", "xiang": "向项項香祥翔湘",
+	"xiao": "肖萧蕭小晓曉笑孝", "xie": "谢謝解", "xin": "辛新欣鑫心馨信", "xing": "邢星兴興幸",
+	"xiong": "熊雄", "xiu": "秀修", "xu": "徐许許旭胥", "xuan": "宣轩軒萱", "xue": "薛雪学學",
+	"xun": "荀勋勳",
+	"yan": "严嚴颜顏闫閆燕艳豔岩彦", "yang": "杨楊阳陽洋扬揚", "yao": "姚耀瑶瑤", "ye": "叶葉业業",
+	"yi": "易一怡逸毅义義艺藝宜仪儀依", "yin": "尹殷银銀音", "ying": "英颖穎莹瑩鹰鷹影",
+	"yong": "永勇雍", "you": "尤游友有", "yu": "于於余餘俞虞玉宇雨语語鱼魚瑜郁钰鈺",
+	"yuan": "袁元源远遠苑媛", "yue": "岳月悦悅越", "yun": "云雲芸韵韻允",
+	"ze": "泽澤则則", "zeng": "曾", "zhai": "翟", "zhan": "詹展战戰", "zhang": "张張章彰",
+	"zhao": "赵趙昭照", "zhe": "哲", "zhen": "甄珍真振震", "zheng": "郑鄭正政峥",
+	"zhi": "志智芝之治知植致", "zhong": "钟鍾鐘中忠仲", "zhou": "周州洲舟", "zhu": "朱祝诸諸竹珠",
+	"zhuang": "庄莊", "zhuo": "卓", "zi": "子紫梓自", "zong": "宗", "zou": "邹鄒",
+}
+
+// chineseCompoundSurnames are the two-character Chinese surnames with their pinyin. Every other
+// Chinese surname is a single character.
+var chineseCompoundSurnames = map[string]string{
+	"欧阳": "ouyang", "歐陽": "ouyang", "司马": "sima", "司馬": "sima", "诸葛": "zhuge", "諸葛": "zhuge",
+	"上官": "shangguan", "司徒": "situ", "慕容": "murong", "东方": "dongfang", "東方": "dongfang",
+	"皇甫": "huangfu", "夏侯": "xiahou", "公孙": "gongsun", "公孫": "gongsun", "令狐": "linghu",
+	"尉迟": "yuchi", "尉遲": "yuchi",
+}
+
+// japaneseSurnameReadings are the Hepburn readings of common Japanese surnames written in kanji
+var japaneseSurnameReadings = map[string]string{
+	"佐藤": "sato", "鈴木": "suzuki", "高橋": "takahashi", "田中": "tanaka", "伊藤": "ito",
+	"渡辺": "watanabe", "渡邊": "watanabe", "山本": "yamamoto", "中村": "nakamura", "小林": "kobayashi",
+	"加藤": "kato", "吉田": "yoshida", "山田": "yamada", "佐々木": "sasaki", "山口": "yamaguchi",
+	"松本": "matsumoto", "井上": "inoue", "木村": "kimura", "林": "hayashi", "斎藤": "saito",
+	"斉藤": "saito", "清水": "shimizu", "山崎": "yamazaki", "森": "mori", "池田": "ikeda",
+	"橋本": "hashimoto", "阿部": "abe", "石川": "ishikawa", "山下": "yamashita", "中島": "nakajima",
+	"石井": "ishii", "小川": "ogawa", "前田": "maeda", "岡田": "okada", "長谷川": "hasegawa",
+	"藤田": "fujita", "後藤": "goto", "近藤": "kondo", "村上": "murakami", "遠藤": "endo",
+	"青木": "aoki", "坂本": "sakamoto", "福田": "fukuda", "太田": "ota", "西村": "nishimura",
+	"藤井": "fujii", "金子": "kaneko", "岡本": "okamoto", "藤原": "fujiwara", "中野": "nakano",
+	"三浦": "miura", "原田": "harada", "松田": "matsuda", "竹内": "takeuchi", "中川": "nakagawa",
+	"小野": "ono", "田村": "tamura", "野口": "noguchi", "上田": "ueda", "森田": "morita",
+	"安藤": "ando", "宮崎": "miyazaki", "大野": "ono", "酒井": "sakai", "工藤": "kudo",
+	"横山": "yokoyama", "宮本": "miyamoto", "内田": "uchida", "高木": "takagi", "谷口": "taniguchi",
+	"松井": "matsui", "丸山": "maruyama", "今井": "imai", "河野": "kono", "藤本": "fujimoto",
+	"村田": "murata", "武田": "takeda", "上野": "ueno", "杉山": "sugiyama", "増田": "masuda",
+	"小山": "koyama", "大塚": "otsuka", "平野": "hirano", "菅原": "sugawara", "久保": "kubo",
+	"松尾": "matsuo", "野村": "nomura", "木下": "kinoshita", "菊地": "kikuchi", "佐野": "sano",
+	"大西": "onishi", "杉本": "sugimoto", "新井": "arai", "浜田": "hamada", "市川": "ichikawa",
+	"古川": "furukawa", "水野": "mizuno", "小島": "kojima", "桜井": "sakurai", "高田": "takada",
+	"宮田": "miyata", "北村": "kitamura", "安田": "yasuda", "中山": "nakayama", "黒田": "kuroda",
+	"本田": "honda", "豊田": "toyoda", "黒澤": "kurosawa", "黒沢": "kurosawa",
+}
+
+// japaneseGivenNameReadings are the Hepburn readings of common Japanese given names written in kanji
+var japaneseGivenNameReadings = map[string]string{
+	"由紀": "yuki", "優希": "yuki", "由希": "yuki", "有紀": "yuki", "雪": "yuki", "裕子": "yuko",
+	"恵子": "keiko", "洋子": "yoko", "陽子": "yoko", "直美": "naomi", "真由美": "mayumi",
+	"美咲": "misaki", "和子": "kazuko", "幸子": "sachiko", "花子": "hanako", "智子": "tomoko",
+	"久美子": "kumiko", "由美": "yumi", "美紀": "miki", "真理子": "mariko", "明美": "akemi",
+	"結衣": "yui", "陽菜": "hina", "愛": "ai", "舞": "mai", "彩": "aya", "綾": "aya",
+	"恵": "megumi", "葵": "aoi", "太郎": "taro", "一郎": "ichiro", "健": "ken", "健太": "kenta",
+	"大輔": "daisuke", "翔太": "shota", "拓也": "takuya", "翔": "sho", "蓮": "ren",
+	"大翔": "hiroto", "悠真": "yuma", "陽翔": "haruto", "明": "akira", "誠": "makoto",
+	"浩": "hiroshi", "博": "hiroshi", "隆": "takashi", "茂": "shigeru", "清": "kiyoshi",
+	"学": "manabu", "正": "tadashi", "勝": "masaru", "稔": "minoru", "修": "osamu",
+	"聡": "satoshi", "亮": "ryo", "涼": "ryo", "健一": "kenichi", "拓海": "takumi",
+	"直樹": "naoki", "和也": "kazuya", "達也": "tatsuya", "哲也": "tetsuya", "雄一": "yuichi",
+	"裕": "yutaka", "優": "yu", "春樹": "haruki", "由紀夫": "yukio", "康成": "yasunari",
+	"駿": "hayao", "健二": "kenji", "賢治": "kenji", "信之": "nobuyuki", "秀樹": "hideki",
+	"英樹": "hideki", "光": "hikaru", "翼": "tsubasa", "大和": "yamato", "剛": "tsuyoshi",
+}
@@ -0,0 +1,94 @@
+package domain
+
+import "strings"
+
+// ColognePhonetic is the Kölner Phonetik encoder, tuned for German names: "Müller" and
+// "Mueller" both encode to "657"
+type ColognePhonetic struct{}
+
+// Encode returns the Kölner Phonetik key of name
+func (ColognePhonetic) Encode(name string) (string, string) {
+	var letters []byte
+	for _, r := range strings.ToUpper(name) {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			letters = append(letters, byte(r))
+		case r == 'Ä':
+			letters = append(letters, 'A')
+		case r == 'Ö':
+			letters = append(letters, 'O')
+		case r == 'Ü':
+			letters = append(letters, 'U')
+		case r == 'ß':
+			letters = append(letters, 'S')
+		}
+	}
+	if len(letters) == 0 {
+		return "", ""
+	}
+
+	at := func(i int) byte {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+	in := func(c byte, set string) bool { return c != 0 && strings.IndexByte(set, c) >= 0 }
+
+	var codes []byte
+	for i, c := range letters {
+		previous, next := at(i-1), at(i+1)
+		switch {
+		case in(c, "AEIJOUY"):
+			codes = append(codes, '0')
+		case c == 'B', c == 'P' && next != 'H':
+			codes = append(codes, '1')
+		case in(c, "DT") && !in(next, "CSZ"):
+			codes = append(codes, '2')
+		case in(c, "FVW"), c == 'P':
+			codes = append(codes, '3')
+		case in(c, "GKQ"):
+			codes = append(codes, '4')
+		case c == 'C' && i == 0:
+			if in(next, "AHKLOQRUX") {
+				codes = append(codes, '4')
+			} else {
+				codes = append(codes, '8')
+			}
+		case c == 'C':
+			if in(next, "AHKOQUX") && !in(previous, "SZ") {
+				codes = append(codes, '4')
+			} else {
+				codes = append(codes, '8')
+			}
+		case c == 'X':
+			if in(previous, "CKQ") {
+				codes = append(codes, '8')
+			} else {
+				codes = append(codes, '4', '8')
+			}
+		case c == 'L':
+			codes = append(codes, '5')
+		case in(c, "MN"):
+			codes = append(codes, '6')
+		case c == 'R':
+			codes = append(codes, '7')
+		case in(c, "DTSZ"):
+			codes = append(codes, '8')
+		}
+		// H is not coded
+	}
+
+	// Collapse repeated codes, then drop every '0' except a leading one
+	var key []byte
+	for i, code := range codes {
+		if i > 0 && code == codes[i-1] {
+			continue
+		}
+		if code == '0' && len(key) > 0 {
+			continue
+		}
+		key = append(key, code)
+	}
+	return string(key), ""
+}
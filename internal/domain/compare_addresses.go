@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// addressMismatchPenalty is the fraction of the address score lost when the house, apartment or
+// postal numbers or the directions of both addresses disagree: the same street with another number
+// is another home, and "N Main St" is another street than "S Main St"
+const addressMismatchPenalty = 0.5
+
+// addressStreetTypeWeight is the share of the street types in the word score: "Main St" and "Oak
+// St" are different streets, "Main St" and "Main Ave" rarely both exist in one town
+const addressStreetTypeWeight = 0.2
+
+// addressStreetTypes are the expanded words that name the kind of a street rather than the street
+var addressStreetTypes = map[string]bool{
+	"street": true, "strasse": true, "avenue": true, "road": true, "boulevard": true, "drive": true,
+	"lane": true, "court": true, "place": true, "square": true, "highway": true, "parkway": true,
+	"calle": true, "carrera": true, "diagonal": true, "transversal": true,
+}
+
+// addressDirections are the expanded compass directions of streets ("N Main St")
+var addressDirections = map[string]bool{
+	"north": true, "south": true, "east": true, "west": true,
+	"northeast": true, "northwest": true, "southeast": true, "southwest": true,
+}
+
+// addressAbbreviations maps the abbreviations common in postal addresses to the word they stand
+// for, in English, Spanish and German ("St" and "Street", "Cra" and "Carrera", "Str" and "Strasse")
+var addressAbbreviations = map[string]string{
+	"st":      "street",
+	"str":     "strasse",
+	"ave":     "avenue",
+	"av":      "avenue",
+	"avda":    "avenue",
+	"avenida": "avenue",
+	"rd":      "road",
+	"blvd":    "boulevard",
+	"dr":      "drive",
+	"ln":      "lane",
+	"ct":      "court",
+	"pl":      "place",
+	"sq":      "square",
+	"hwy":     "highway",
+	"pkwy":    "parkway",
+	"apt":     "apartment",
+	"apto":    "apartment",
+	"ste":     "suite",
+	"fl":      "floor",
+	"bldg":    "building",
+	"n":       "north",
+	"s":       "south",
+	"e":       "east",
+	"w":       "west",
+	"ne":      "northeast",
+	"nw":      "northwest",
+	"se":      "southeast",
+	"sw":      "southwest",
+	"cl":      "calle",
+	"cll":     "calle",
+	"cra":     "carrera",
+	"kr":      "carrera",
+	"kra":     "carrera",
+	"dg":      "diagonal",
+	"tv":      "transversal",
+}
+
+// addressFillers are the words that only introduce a number ("No. 45", "Nr. 5")
+var addressFillers = map[string]bool{"no": true, "nr": true, "num": true, "number": true, "numero": true}
+
+// NormalizeAddress standardizes a postal address for comparison: normalized like an organization
+// name (see NormalizeOrganizationName), with abbreviations expanded ("Ave" is "avenue", "Hauptstr"
+// is "hauptstrasse", "straße" is "strasse"), ordinal suffixes dropped ("5th" is "5") and filler
+// words left out ("No. 45")
+func NormalizeAddress(address string) string {
+	var tokens []string
+	for _, token := range strings.Fields(NormalizeOrganizationName(strings.ReplaceAll(address, "ß", "ss"))) {
+		switch expanded, ok := addressAbbreviations[token]; {
+		case ok:
+			token = expanded
+		case addressFillers[token]:
+			continue
+		case isOrdinal(token):
+			token = strings.TrimRightFunc(token, unicode.IsLetter)
+		case len(token) > len("str") && strings.HasSuffix(token, "str"):
+			token += "asse"
+		}
+		tokens = append(tokens, token)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// isOrdinal reports whether a token is a number with an English ordinal suffix ("1st", "22nd")
+func isOrdinal(token string) bool {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if number, ok := strings.CutSuffix(token, suffix); ok && number != "" && strings.Trim(number, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareAddresses compares two postal addresses with the default matcher (see NameMatcher.CompareAddresses)
+func CompareAddresses(address1, address2 string) FieldMatch {
+	return defaultNameMatcher.CompareAddresses(address1, address2)
+}
+
+// CompareAddresses compares two postal addresses (see NormalizeAddress). Words, street types and
+// numbers are scored apart: the words are aligned one to one and scored as sets like organization
+// names, so their order does not matter and an address contained in the other still scores well;
+// the street types, when both addresses carry one, only weigh the street type weight. Numbers and
+// directions must agree exactly, but those of the shorter address only need to appear in the
+// other, as one may leave out the apartment or the postal code; any that disagree cost the
+// address mismatch penalty.
+func (m *NameMatcher) CompareAddresses(address1, address2 string) FieldMatch {
+	match := FieldMatch{
+		Value1:      address1,
+		Value2:      address2,
+		Normalized1: NormalizeAddress(address1),
+		Normalized2: NormalizeAddress(address2),
+		Rule:        RuleAddressComponents,
+	}
+	if match.Normalized1 == "" || match.Normalized2 == "" {
+		return match
+	}
+	if match.Normalized1 == match.Normalized2 {
+		match.Rule, match.Score = RuleAddressExact, 1.0
+		return match
+	}
+
+	words1, types1, numbers1 := splitAddressTokens(match.Normalized1)
+	words2, types2, numbers2 := splitAddressTokens(match.Normalized2)
+	match.Score = 1.0
+	if len(words1) > 0 && len(words2) > 0 {
+		_, _, _, setScore, containment := m.compareTokenSets(words1, words2)
+		match.Score = (setScore + containment) / 2
+	} else if len(words1) != len(words2) {
+		match.Score = 0.0
+	}
+	if len(types1) > 0 && len(types2) > 0 {
+		_, _, _, setScore, containment := m.compareTokenSets(types1, types2)
+		match.Score = (1-addressStreetTypeWeight)*match.Score + addressStreetTypeWeight*(setScore+containment)/2
+	}
+	if len(numbers1) > len(numbers2) {
+		numbers1, numbers2 = numbers2, numbers1
+	}
+	for _, number := range numbers1 {
+		if !slices.Contains(numbers2, number) {
+			match.Score *= 1 - addressMismatchPenalty
+			break
+		}
+	}
+	return match
+}
+
+// splitAddressTokens splits the tokens of a normalized address into words, street types and the
+// tokens that must agree exactly: numbers, tokens with a digit ("12", "4b"), and directions
+func splitAddressTokens(normalized string) (words, streetTypes, numbers []string) {
+	for _, token := range strings.Fields(normalized) {
+		switch {
+		case strings.ContainsFunc(token, unicode.IsDigit) || addressDirections[token]:
+			numbers = append(numbers, token)
+		case addressStreetTypes[token]:
+			streetTypes = append(streetTypes, token)
+		default:
+			words = append(words, token)
+		}
+	}
+	return words, streetTypes, numbers
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := map[string]string{
+		"12 Main St., Apt. #4B":    "12 main street apartment 4b",
+		"Hauptstraße 5":            "hauptstrasse 5",
+		"Hauptstr. 5":              "hauptstrasse 5",
+		"Cra. 7 No. 45-10, Bogotá": "carrera 7 45 10 bogota",
+		"350 5th Ave":              "350 5 avenue",
+	}
+	for address, want := range tests {
+		if got := NormalizeAddress(address); got != want {
+			t.Errorf("NormalizeAddress(%q) = %q, want %q", address, got, want)
+		}
+	}
+}
+
+func TestCompareAddresses(t *testing.T) {
+	if match := CompareAddresses("Carrera 7 # 45-10, Bogota", "Cra. 7 No. 45-10, Bogotá"); match.Rule != RuleAddressExact || match.Score != 1.0 {
+		t.Errorf("Expected abbreviated addresses to match exactly, got %.2f (%s)", match.Score, match.Rule)
+	}
+
+	partial := CompareAddresses("12 Main St., Apt 4B, Springfield", "12 Main Street, Springfield")
+	if partial.Score < 0.8 {
+		t.Errorf("Expected an address without apartment to match closely, got %.2f", partial.Score)
+	}
+	neighbour := CompareAddresses("12 Main Street", "14 Main Street")
+	if neighbour.Score > 0.5 {
+		t.Errorf("Expected another house number to halve the score, got %.2f", neighbour.Score)
+	}
+	if other := CompareAddresses("12 Main Street", "12 Oak Avenue"); other.Score > 0.3 {
+		t.Errorf("Expected another street not to match, got %.2f", other.Score)
+	}
+	for _, pair := range [][2]string{{"12 N Main St", "12 S Main St"}, {"123 Main St", "123 Oak St"}} {
+		if other := CompareAddresses(pair[0], pair[1]); other.Score > 0.5 {
+			t.Errorf("Expected '%s' and '%s' to be different streets, got %.2f", pair[0], pair[1], other.Score)
+		}
+	}
+	if match := CompareAddresses("12 N Main St", "12 Main St"); match.Score < 0.8 {
+		t.Errorf("Expected a direction left out to match closely, got %.2f", match.Score)
+	}
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// FieldMatch is the outcome of comparing one attribute of two customer records. Score is in [0,1].
+type FieldMatch struct {
+	Field       CustomerField `json:"field"`
+	Value1      string        `json:"value1"`
+	Value2      string        `json:"value2"`
+	Normalized1 string        `json:"normalized1,omitempty"`
+	Normalized2 string        `json:"normalized2,omitempty"`
+	Rule        MatchRule     `json:"rule"`
+	Score       float64       `json:"score"`
+	// Weight is the share of the field in the customer score (see ScoringConfig.FieldWeight)
+	Weight float64 `json:"weight"`
+	// Capped is set when the score was lowered to what the other fields support, as emails that are
+	// disposable, role accounts or invalid may lower the confidence but never raise it
+	Capped bool `json:"capped,omitempty"`
+}
+
+// FieldComparator compares the values of one attribute of two customer records. Values are given
+// as found in the records, neither empty; the comparator normalizes them.
+type FieldComparator interface {
+	CompareField(value1, value2 string) FieldMatch
+}
+
+// FieldComparatorFunc adapts a function to a FieldComparator
+type FieldComparatorFunc func(value1, value2 string) FieldMatch
+
+// CompareField calls f(value1, value2)
+func (f FieldComparatorFunc) CompareField(value1, value2 string) FieldMatch {
+	return f(value1, value2)
+}
+
+// WithFieldComparator replaces the comparator of a customer attribute; nil leaves the attribute
+// out of customer comparisons. Without it every attribute has its built-in comparator.
+func WithFieldComparator(field CustomerField, comparator FieldComparator) MatcherOption {
+	return func(m *NameMatcher) {
+		m.fieldComparators[field] = comparator
+	}
+}
+
+// defaultFieldComparators returns the built-in comparators of every customer attribute
+func (m *NameMatcher) defaultFieldComparators() map[CustomerField]FieldComparator {
+	return map[CustomerField]FieldComparator{
+		FieldName:        FieldComparatorFunc(m.compareNameField),
+		FieldEmail:       FieldComparatorFunc(m.compareEmailField),
+		FieldDateOfBirth: DateOfBirthComparator{MonthFirst: m.config.DateOfBirthMonthFirst},
+		FieldPhone:       FieldComparatorFunc(ComparePhones),
+		FieldAddress:     FieldComparatorFunc(m.CompareAddresses),
+		FieldNationalID:  FieldComparatorFunc(CompareNationalIDs),
+	}
+}
+
+// compareNameField compares two names (see NameMatcher.Compare)
+func (m *NameMatcher) compareNameField(name1, name2 string) FieldMatch {
+	result := m.Compare(name1, name2)
+	return FieldMatch{
+		Value1:      name1,
+		Value2:      name2,
+		Normalized1: result.Normalized1,
+		Normalized2: result.Normalized2,
+		Rule:        result.Rule,
+		Score:       result.Score,
+	}
+}
+
+// compareEmailField compares two email addresses (see NameMatcher.CompareEmails)
+func (m *NameMatcher) compareEmailField(email1, email2 string) FieldMatch {
+	result := m.CompareEmails(email1, email2)
+	return FieldMatch{
+		Value1:      email1,
+		Value2:      email2,
+		Normalized1: result.Canonical1,
+		Normalized2: result.Canonical2,
+		Rule:        result.Rule,
+		Score:       result.Score,
+	}
+}
+
+// CompareField compares the values of one customer attribute with its comparator (see
+// WithFieldComparator). An attribute without comparator scores 0.0.
+func (m *NameMatcher) CompareField(field CustomerField, value1, value2 string) FieldMatch {
+	match := FieldMatch{Value1: value1, Value2: value2}
+	if comparator := m.fieldComparators[field]; comparator != nil {
+		match = comparator.CompareField(value1, value2)
+	}
+	match.Field, match.Weight = field, m.config.FieldWeight(field)
+	return match
+}
+
+// CustomerMatchResult is the structured outcome of a customer comparison, with one FieldMatch per
+// attribute both records carry. Score is in [0,1].
+type CustomerMatchResult struct {
+	Fields []FieldMatch `json:"fields"`
+	// Missing are the attributes at least one record lacks or holds a placeholder for, left out of the score
+	Missing []CustomerField `json:"missing,omitempty"`
+	// EmailRisk1 and EmailRisk2 flag the email addresses of both records (see ClassifyEmail)
+	EmailRisk1 EmailRisk `json:"email_risk1"`
+	EmailRisk2 EmailRisk `json:"email_risk2"`
+	Score      float64   `json:"score"`
+}
+
+// Field returns the comparison of an attribute, or false when it was not compared
+func (r CustomerMatchResult) Field(field CustomerField) (FieldMatch, bool) {
+	for _, match := range r.Fields {
+		if match.Field == field {
+			return match, true
+		}
+	}
+	return FieldMatch{}, false
+}
+
+// CompareCustomers compares two customer records with the default matcher (see NameMatcher.CompareCustomers)
+func CompareCustomers(customer1, customer2 *Customer) CustomerMatchResult {
+	return defaultNameMatcher.CompareCustomers(customer1, customer2)
+}
+
+// CompareCustomers compares every attribute two customer records both carry with its comparator
+// (see WithFieldComparator) and combines the field scores weighted by ScoringConfig.FieldWeight.
+// Attributes missing from either record, or holding placeholders its comparator cannot use (see
+// RuleUnusableValue), are neither evidence for nor against a match and are left out. The email
+// score is capped at the combined score of the other fields when either address is disposable, a
+// role account or invalid (see ClassifyEmail): two customers sharing a mailinator address are no
+// more likely the same person for it. An email with nothing else to compare scores 0.0.
+func (m *NameMatcher) CompareCustomers(customer1, customer2 *Customer) CustomerMatchResult {
+	result := CustomerMatchResult{
+		EmailRisk1: m.ClassifyEmail(customer1.Email),
+		EmailRisk2: m.ClassifyEmail(customer2.Email),
+	}
+	for _, field := range CustomerFields {
+		if m.fieldComparators[field] == nil {
+			continue
+		}
+		value1, value2 := customer1.Field(field), customer2.Field(field)
+		if strings.TrimSpace(value1) == "" || strings.TrimSpace(value2) == "" {
+			result.Missing = append(result.Missing, field)
+			continue
+		}
+		match := m.CompareField(field, value1, value2)
+		if match.Rule == RuleUnusableValue {
+			result.Missing = append(result.Missing, field)
+			continue
+		}
+		result.Fields = append(result.Fields, match)
+	}
+
+	if result.EmailRisk1.Flagged() || result.EmailRisk2.Flagged() {
+		var others []FieldMatch
+		for _, match := range result.Fields {
+			if match.Field != FieldEmail {
+				others = append(others, match)
+			}
+		}
+		supported := combineFieldMatches(others)
+		for i, match := range result.Fields {
+			if match.Field == FieldEmail && match.Score > supported {
+				result.Fields[i].Score, result.Fields[i].Capped = supported, true
+			}
+		}
+	}
+	result.Score = combineFieldMatches(result.Fields)
+
+	m.logger.LogAttrs(context.Background(), slog.LevelDebug, "customer comparison",
+		slog.Float64("score", result.Score),
+		slog.Int("fields", len(result.Fields)),
+		slog.Int("missing", len(result.Missing)),
+	)
+	return result
+}
+
+// combineFieldMatches returns the average of field scores weighted by their weights, 0.0 for none
+func combineFieldMatches(matches []FieldMatch) float64 {
+	scores, weights := make([]float64, len(matches)), make([]float64, len(matches))
+	for i, match := range matches {
+		scores[i], weights[i] = match.Score, match.Weight
+	}
+	return weightedAverage(scores, weights)
+}
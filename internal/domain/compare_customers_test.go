@@ -0,0 +1,89 @@
+package domain
+
+import "testing"
+
+func TestCompareCustomersCombinesPresentFields(t *testing.T) {
+	customer1 := &Customer{Name: "John Smith", Email: "john.smith@example.com", DateOfBirth: "1985-03-07", NationalID: "123.456.789"}
+	customer2 := &Customer{Name: "John Smith", Email: "jsmith@example.org", DateOfBirth: "1985/3/7", Phone: "+1 555 123 4567", NationalID: "0123456789"}
+	result := CompareCustomers(customer1, customer2)
+
+	if len(result.Fields) != 4 || len(result.Missing) != 2 {
+		t.Fatalf("Expected name, email, date of birth and national ID to be compared, got %+v", result)
+	}
+	if dob, _ := result.Field(FieldDateOfBirth); dob.Rule != RuleDateOfBirthExact {
+		t.Errorf("Expected the same date of birth in two layouts, got %+v", dob)
+	}
+	if id, _ := result.Field(FieldNationalID); id.Rule != RuleNationalIDExact {
+		t.Errorf("Expected the same national ID with and without separators and leading zero, got %+v", id)
+	}
+	if _, ok := result.Field(FieldPhone); ok {
+		t.Errorf("Expected the phone to be left out when one record lacks it")
+	}
+
+	email, _ := result.Field(FieldEmail)
+	config := DefaultScoringConfig()
+	want := (config.NameWeight + email.Score*config.EmailWeight + config.DateOfBirthWeight + config.NationalIDWeight) /
+		(config.NameWeight + config.EmailWeight + config.DateOfBirthWeight + config.NationalIDWeight)
+	if diff := result.Score - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("Expected the weighted average %.4f, got %.4f", want, result.Score)
+	}
+}
+
+func TestCompareCustomersCapsUntrustedEmails(t *testing.T) {
+	customer1 := &Customer{Name: "John Smith", Email: "test@mailinator.com"}
+	customer2 := &Customer{Name: "Mary Jones", Email: "test@mailinator.com"}
+	result := CompareCustomers(customer1, customer2)
+
+	name, _ := result.Field(FieldName)
+	email, _ := result.Field(FieldEmail)
+	if !result.EmailRisk1.Disposable || !email.Capped || email.Score != name.Score || result.Score != name.Score {
+		t.Errorf("Expected a shared disposable address not to raise the name score %.2f, got %+v", name.Score, result)
+	}
+
+	emailOnly := CompareCustomers(&Customer{Email: "info@example.com"}, &Customer{Email: "info@example.com"})
+	if emailOnly.Score != 0.0 {
+		t.Errorf("Expected a role address alone to be no evidence, got %.2f", emailOnly.Score)
+	}
+}
+
+func TestWithFieldComparator(t *testing.T) {
+	sameYear := FieldComparatorFunc(func(date1, date2 string) FieldMatch {
+		if date1[:4] == date2[:4] {
+			return FieldMatch{Score: 1.0}
+		}
+		return FieldMatch{}
+	})
+	matcher := NewNameMatcher(DefaultScoringConfig(),
+		WithFieldComparator(FieldDateOfBirth, sameYear),
+		WithFieldComparator(FieldEmail, nil),
+	)
+	customer1 := &Customer{Name: "Ana Ruiz", Email: "ana@example.com", DateOfBirth: "1990-01-01"}
+	customer2 := &Customer{Name: "Ana Ruiz", Email: "ruiz@example.net", DateOfBirth: "1990-12-31"}
+	result := matcher.CompareCustomers(customer1, customer2)
+
+	if _, ok := result.Field(FieldEmail); ok {
+		t.Errorf("Expected a nil comparator to leave the email out")
+	}
+	if result.Score != 1.0 {
+		t.Errorf("Expected the custom date of birth comparator to match, got %.2f", result.Score)
+	}
+}
+
+func TestCompareCustomersIgnoresPlaceholderIDs(t *testing.T) {
+	if match := CompareNationalIDs("N/A", "N/A"); match.Rule != RuleUnusableValue || match.Score != 0.0 {
+		t.Errorf("Expected 'N/A' not to be an ID, got %.2f (%s)", match.Score, match.Rule)
+	}
+	if match := CompareNationalIDs("000-00-0000", "000000000"); match.Rule != RuleUnusableValue {
+		t.Errorf("Expected an all-zero ID not to be an ID, got %.2f (%s)", match.Score, match.Rule)
+	}
+
+	customer1 := &Customer{Name: "John Smith", NationalID: "N/A"}
+	customer2 := &Customer{Name: "Peter Jones", NationalID: "N/A"}
+	result := CompareCustomers(customer1, customer2)
+	if _, ok := result.Field(FieldNationalID); ok || len(result.Missing) == 0 {
+		t.Errorf("Expected placeholder IDs to be left out as missing, got %+v", result)
+	}
+	if name, _ := result.Field(FieldName); result.Score != name.Score {
+		t.Errorf("Expected only the names to count, got %.2f", result.Score)
+	}
+}
@@ -0,0 +1,311 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Precisions of partial dates: how many of year, month and day are known
+const (
+	precisionYear      = 1
+	precisionYearMonth = 2
+	precisionFullDate  = 3
+)
+
+// datePrecisionWeights is how strongly two dates agreeing to a precision point to the same person:
+// many more people share a birth year than a birth date
+var datePrecisionWeights = map[int]float64{
+	precisionYear:      0.7,
+	precisionYearMonth: 0.9,
+	precisionFullDate:  1.0,
+}
+
+// dateOfBirthErrorWeights is the share of the score kept for dates that differ by a common
+// data-entry error
+var dateOfBirthErrorWeights = map[MatchRule]float64{
+	RuleDateOfBirthTransposed:   0.9,
+	RuleDateOfBirthCentury:      0.9,
+	RuleDateOfBirthDigitTypo:    0.8,
+	RuleDateOfBirthYearOffByOne: 0.8,
+}
+
+// monthNames maps English and Spanish month names and their abbreviations to month numbers
+var monthNames = map[string]int{
+	"january": 1, "jan": 1, "enero": 1, "ene": 1,
+	"february": 2, "feb": 2, "febrero": 2,
+	"march": 3, "mar": 3, "marzo": 3,
+	"april": 4, "apr": 4, "abril": 4, "abr": 4,
+	"may": 5, "mayo": 5,
+	"june": 6, "jun": 6, "junio": 6,
+	"july": 7, "jul": 7, "julio": 7,
+	"august": 8, "aug": 8, "agosto": 8, "ago": 8,
+	"september": 9, "sep": 9, "sept": 9, "septiembre": 9, "setiembre": 9, "set": 9,
+	"october": 10, "oct": 10, "octubre": 10,
+	"november": 11, "nov": 11, "noviembre": 11,
+	"december": 12, "dec": 12, "diciembre": 12, "dic": 12,
+}
+
+// dateFillers are the words written between the parts of a date ("7 de marzo de 1985", "the 7th of March")
+var dateFillers = map[string]bool{"de": true, "del": true, "of": true, "the": true}
+
+// PartialDate is a date of birth of which only the year, or the year and month, may be known.
+// Month and Day are zero when unknown.
+type PartialDate struct {
+	Year  int `json:"year"`
+	Month int `json:"month,omitempty"`
+	Day   int `json:"day,omitempty"`
+}
+
+// String returns the date in ISO 8601 form to its precision ("1985-03-07", "1985-03", "1985")
+func (d PartialDate) String() string {
+	switch d.precision() {
+	case precisionFullDate:
+		return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	case precisionYearMonth:
+		return fmt.Sprintf("%04d-%02d", d.Year, d.Month)
+	}
+	return fmt.Sprintf("%04d", d.Year)
+}
+
+// precision returns how many of year, month and day are known
+func (d PartialDate) precision() int {
+	switch {
+	case d.Month == 0:
+		return precisionYear
+	case d.Day == 0:
+		return precisionYearMonth
+	}
+	return precisionFullDate
+}
+
+// truncate returns the date reduced to a precision
+func (d PartialDate) truncate(precision int) PartialDate {
+	if precision < precisionFullDate {
+		d.Day = 0
+	}
+	if precision < precisionYearMonth {
+		d.Month = 0
+	}
+	return d
+}
+
+// digits returns the known components of the date as digits ("19850307")
+func (d PartialDate) digits() string {
+	return strings.ReplaceAll(d.String(), "-", "")
+}
+
+// ParseDateOfBirth parses a date of birth written day first where the order is ambiguous (see
+// DateOfBirthComparator.Parse)
+func ParseDateOfBirth(date string) (PartialDate, bool) {
+	return DateOfBirthComparator{}.Parse(date)
+}
+
+// CompareDatesOfBirth compares two dates of birth written day first where the order is ambiguous
+// (see DateOfBirthComparator.CompareField)
+func CompareDatesOfBirth(date1, date2 string) FieldMatch {
+	return DateOfBirthComparator{}.CompareField(date1, date2)
+}
+
+// DateOfBirthComparator compares dates of birth, forgiving the errors common in data entry
+type DateOfBirthComparator struct {
+	// MonthFirst reads numeric dates whose order is ambiguous month first ("03/07/1985" is March 7,
+	// as in the US) instead of day first (July 3)
+	MonthFirst bool
+}
+
+// Parse parses a date of birth, in full or partial. Accepted forms are year first ("1985-03-07",
+// "1985/3/7", "19850307", "1985-03", "1985"), numeric with the year last ("07/03/1985", "7.3.85",
+// "07031985", "03-1985") and with English or Spanish month names ("7 March 1985", "Mar 7, 1985", "the 7th of
+// March 1985", "7 de marzo de 1985"). Numeric dates are read day first unless MonthFirst is set or
+// only the other order is a valid date. Two-digit years are read as the latest year not in the future.
+func (c DateOfBirthComparator) Parse(date string) (PartialDate, bool) {
+	fields := strings.FieldsFunc(strings.ToLower(date), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var numbers []string
+	month := 0
+	for _, field := range fields {
+		switch {
+		case strings.Trim(field, "0123456789") == "":
+			numbers = append(numbers, field)
+		case isOrdinal(field):
+			numbers = append(numbers, strings.TrimRightFunc(field, unicode.IsLetter))
+		case monthNames[field] > 0 && month == 0:
+			month = monthNames[field]
+		case dateFillers[field]:
+		default:
+			return PartialDate{}, false
+		}
+	}
+
+	var d PartialDate
+	var ok bool
+	if month > 0 {
+		d, ok = parseNamedMonthDate(numbers, month)
+	} else {
+		d, ok = c.parseNumericDate(numbers)
+	}
+	if !ok || !validPartialDate(d) {
+		return PartialDate{}, false
+	}
+	return d, true
+}
+
+// parseNamedMonthDate reads the year and the optional day that come with a month name
+func parseNamedMonthDate(numbers []string, month int) (PartialDate, bool) {
+	d := PartialDate{Month: month}
+	switch len(numbers) {
+	case 1:
+		d.Year = parseYear(numbers[0])
+	case 2:
+		// the year is the number with four digits or that cannot be a day, otherwise the last
+		day, year := numbers[0], numbers[1]
+		if len(day) == 4 || atoi(day) > 31 {
+			day, year = year, day
+		}
+		d.Year, d.Day = parseYear(year), atoi(day)
+	default:
+		return PartialDate{}, false
+	}
+	return d, d.Day >= 0
+}
+
+// parseNumericDate reads a date written in numbers only
+func (c DateOfBirthComparator) parseNumericDate(numbers []string) (PartialDate, bool) {
+	switch len(numbers) {
+	case 1:
+		// compact dates are year first when that is a valid date, otherwise day or month first with
+		// the year last ("19850307", "07031985", "070385")
+		switch n := numbers[0]; len(n) {
+		case 4:
+			return PartialDate{Year: atoi(n)}, true
+		case 6:
+			if d := (PartialDate{Year: atoi(n[:4]), Month: atoi(n[4:])}); validPartialDate(d) {
+				return d, true
+			}
+			return c.parseNumericDate([]string{n[:2], n[2:4], n[4:]})
+		case 8:
+			if d := (PartialDate{Year: atoi(n[:4]), Month: atoi(n[4:6]), Day: atoi(n[6:])}); validPartialDate(d) && d.Day > 0 {
+				return d, true
+			}
+			return c.parseNumericDate([]string{n[:2], n[2:4], n[4:]})
+		}
+	case 2:
+		if len(numbers[0]) == 4 {
+			return PartialDate{Year: atoi(numbers[0]), Month: atoi(numbers[1])}, true
+		}
+		if len(numbers[1]) == 4 {
+			return PartialDate{Year: atoi(numbers[1]), Month: atoi(numbers[0])}, true
+		}
+	case 3:
+		if len(numbers[0]) == 4 {
+			return PartialDate{Year: atoi(numbers[0]), Month: atoi(numbers[1]), Day: atoi(numbers[2])}, true
+		}
+		first, second := atoi(numbers[0]), atoi(numbers[1])
+		d := PartialDate{Year: parseYear(numbers[2]), Day: first, Month: second}
+		if c.MonthFirst && first <= 12 || second > 12 {
+			d.Day, d.Month = second, first
+		}
+		return d, true
+	}
+	return PartialDate{}, false
+}
+
+// parseYear reads a year of four digits, or of two as the latest such year not in the future
+// ("85" is 1985, "07" is 2007), or returns -1
+func parseYear(year string) int {
+	switch len(year) {
+	case 4:
+		return atoi(year)
+	case 2:
+		current := time.Now().Year()
+		resolved := current - current%100 + atoi(year)
+		if resolved > current {
+			resolved -= 100
+		}
+		return resolved
+	}
+	return -1
+}
+
+// atoi returns the value of a string of digits, or -1
+func atoi(digits string) int {
+	n, err := strconv.Atoi(digits)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// validPartialDate reports whether a partial date names an existing year, month and day
+func validPartialDate(d PartialDate) bool {
+	if d.Year < 1000 || d.Year > 9999 || d.Month < 0 || d.Month > 12 || d.Day < 0 || d.Day > 0 && d.Month == 0 {
+		return false
+	}
+	if d.Day > 0 {
+		t := time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC)
+		return t.Day() == d.Day
+	}
+	return true
+}
+
+// CompareField compares two dates of birth (see Parse) and names the data-entry error that tells
+// them apart. Dates are compared to the precision of the less precise one, so "1985-03" agrees with
+// "1985-03-07"; agreeing dates score less the less precise they are. Dates that differ score a
+// share of that for a single error:
+//   - day and month transposed, US against European order ("1985-03-07" and "1985-07-03")
+//   - the century, a two-digit year expanded the wrong way ("1985" and "2085")
+//   - the year off by one ("1985" and "1986")
+//   - one mistyped digit or two swapped adjacent digits ("1985-03-07" and "1958-03-07")
+//
+// Any other dates score 0.0. Dates that cannot be parsed ("unknown", "0/0/0") are no date at all
+// and score 0.0 under RuleUnusableValue, which leaves them out of customer comparisons.
+func (c DateOfBirthComparator) CompareField(date1, date2 string) FieldMatch {
+	match := FieldMatch{
+		Value1:      date1,
+		Value2:      date2,
+		Normalized1: strings.TrimSpace(date1),
+		Normalized2: strings.TrimSpace(date2),
+		Rule:        RuleDateOfBirthMismatch,
+	}
+	parsed1, ok1 := c.Parse(date1)
+	parsed2, ok2 := c.Parse(date2)
+	if ok1 {
+		match.Normalized1 = parsed1.String()
+	}
+	if ok2 {
+		match.Normalized2 = parsed2.String()
+	}
+	if !ok1 || !ok2 {
+		match.Rule = RuleUnusableValue
+		return match
+	}
+
+	precision := min(parsed1.precision(), parsed2.precision())
+	d1, d2 := parsed1.truncate(precision), parsed2.truncate(precision)
+	weight := datePrecisionWeights[precision]
+	switch {
+	case d1 == d2 && precision == precisionFullDate:
+		match.Rule, match.Score = RuleDateOfBirthExact, 1.0
+		return match
+	case d1 == d2:
+		match.Rule, match.Score = RuleDateOfBirthPartial, weight
+		return match
+	case precision == precisionFullDate && d1.Year == d2.Year && d1.Day == d2.Month && d1.Month == d2.Day:
+		match.Rule = RuleDateOfBirthTransposed
+	case d1.Month == d2.Month && d1.Day == d2.Day && abs(d1.Year-d2.Year) == 100:
+		match.Rule = RuleDateOfBirthCentury
+	case d1.Month == d2.Month && d1.Day == d2.Day && abs(d1.Year-d2.Year) == 1:
+		match.Rule = RuleDateOfBirthYearOffByOne
+	case damerauLevenshteinDistance([]rune(d1.digits()), []rune(d2.digits())) == 1:
+		match.Rule = RuleDateOfBirthDigitTypo
+	default:
+		return match
+	}
+	match.Score = weight * dateOfBirthErrorWeights[match.Rule]
+	return match
+}
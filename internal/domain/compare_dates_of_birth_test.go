@@ -0,0 +1,86 @@
+package domain
+
+import "testing"
+
+func TestParseDateOfBirth(t *testing.T) {
+	tests := map[string]string{
+		"1985-03-07":            "1985-03-07",
+		"1985/3/7":              "1985-03-07",
+		"19850307":              "1985-03-07",
+		"07/03/1985":            "1985-03-07",
+		"7.3.85":                "1985-03-07",
+		"03/25/1985":            "1985-03-25",
+		"7 March 1985":          "1985-03-07",
+		"Mar 7, 1985":           "1985-03-07",
+		"the 7th of March 1985": "1985-03-07",
+		"7 de marzo de 1985":    "1985-03-07",
+		"1985-03":               "1985-03",
+		"03/1985":               "1985-03",
+		"marzo 1985":            "1985-03",
+		"1985":                  "1985",
+	}
+	for date, want := range tests {
+		parsed, ok := ParseDateOfBirth(date)
+		if !ok || parsed.String() != want {
+			t.Errorf("ParseDateOfBirth(%q) = %s (%t), want %s", date, parsed, ok, want)
+		}
+	}
+
+	for _, date := range []string{"", "31/02/1985", "1985-13-01", "7 March", "unknown", "85"} {
+		if parsed, ok := ParseDateOfBirth(date); ok {
+			t.Errorf("Expected %q not to parse, got %s", date, parsed)
+		}
+	}
+}
+
+func TestParseDateOfBirthMonthFirst(t *testing.T) {
+	parsed, ok := DateOfBirthComparator{MonthFirst: true}.Parse("03/07/1985")
+	if !ok || parsed.String() != "1985-03-07" {
+		t.Errorf("Expected '03/07/1985' to be March 7 month first, got %s", parsed)
+	}
+	parsed, ok = DateOfBirthComparator{MonthFirst: true}.Parse("25/03/1985")
+	if !ok || parsed.String() != "1985-03-25" {
+		t.Errorf("Expected '25/03/1985' to be read day first as the only valid order, got %s", parsed)
+	}
+}
+
+func TestCompareDatesOfBirth(t *testing.T) {
+	tests := []struct {
+		date1, date2 string
+		rule         MatchRule
+		score        float64
+	}{
+		{"1985-03-07", "07/03/1985", RuleDateOfBirthExact, 1.0},
+		{"1985-03-07", "1985-07-03", RuleDateOfBirthTransposed, 0.9},
+		{"1985-03-07", "2085-03-07", RuleDateOfBirthCentury, 0.9},
+		{"1985-03-07", "1986-03-07", RuleDateOfBirthYearOffByOne, 0.8},
+		{"1985-03-07", "1985-03-08", RuleDateOfBirthDigitTypo, 0.8},
+		{"1985-03-07", "1958-03-07", RuleDateOfBirthDigitTypo, 0.8},
+		{"1985-03", "1985-03-07", RuleDateOfBirthPartial, 0.9},
+		{"1985", "7 March 1985", RuleDateOfBirthPartial, 0.7},
+		{"1985", "1986-01-01", RuleDateOfBirthYearOffByOne, 0.7 * 0.8},
+		{"1985-03-07", "1990-11-23", RuleDateOfBirthMismatch, 0.0},
+		{"unknown", "unknown", RuleUnusableValue, 0.0},
+		{"0/0/0", "0/0/0", RuleUnusableValue, 0.0},
+		{"N/A", "1985-03-07", RuleUnusableValue, 0.0},
+	}
+	for _, test := range tests {
+		match := CompareDatesOfBirth(test.date1, test.date2)
+		if match.Rule != test.rule || match.Score < test.score-1e-9 || match.Score > test.score+1e-9 {
+			t.Errorf("CompareDatesOfBirth(%q, %q) = %.2f (%s), want %.2f (%s)", test.date1, test.date2, match.Score, match.Rule, test.score, test.rule)
+		}
+	}
+}
+
+func TestCompareCustomersIgnoresUnreadableDatesOfBirth(t *testing.T) {
+	customer1 := &Customer{Name: "John Smith", DateOfBirth: "unknown"}
+	customer2 := &Customer{Name: "Peter Jones", DateOfBirth: "unknown"}
+	result := CompareCustomers(customer1, customer2)
+
+	if _, ok := result.Field(FieldDateOfBirth); ok {
+		t.Errorf("Expected unreadable dates of birth to be left out, got %+v", result)
+	}
+	if name, _ := result.Field(FieldName); result.Score != name.Score {
+		t.Errorf("Expected only the names to count, got %.2f", result.Score)
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+)
+
+// EmailMatchResult is the structured outcome of an email comparison, with the local parts and the
+// domains scored separately. All scores are in [0,1].
+type EmailMatchResult struct {
+	Email1     string `json:"email1"`
+	Email2     string `json:"email2"`
+	Canonical1 string `json:"canonical1"`
+	Canonical2 string `json:"canonical2"`
+	Local1     string `json:"local1"`
+	Local2     string `json:"local2"`
+	Domain1    string `json:"domain1"`
+	Domain2    string `json:"domain2"`
+	// DomainSuggestion1 and DomainSuggestion2 are the popular provider domains the domains are
+	// likely typos of (see SuggestEmailDomain)
+	DomainSuggestion1 string `json:"domain_suggestion1,omitempty"`
+	DomainSuggestion2 string `json:"domain_suggestion2,omitempty"`
+	// DomainTypo is set when the domains differ by what is likely a typo
+	DomainTypo  bool      `json:"domain_typo"`
+	LocalScore  float64   `json:"local_score"`
+	DomainScore float64   `json:"domain_score"`
+	Rule        MatchRule `json:"rule"`
+	Score       float64   `json:"score"`
+	// Risk1 and Risk2 flag addresses that are weak evidence of identity (see ClassifyEmail)
+	Risk1 EmailRisk `json:"risk1"`
+	Risk2 EmailRisk `json:"risk2"`
+}
+
+// Trusted reports whether neither address is disposable, a role account or invalid, so their
+// similarity is evidence that the two records belong to the same person. Two customers sharing
+// a mailinator address or info@ of their employer are not the same person for it.
+func (r EmailMatchResult) Trusted() bool {
+	return !r.Risk1.Flagged() && !r.Risk2.Flagged()
+}
+
+// CompareEmails compares two email addresses with the default matcher (see NameMatcher.CompareEmails)
+func CompareEmails(email1, email2 string) EmailMatchResult {
+	return defaultNameMatcher.CompareEmails(email1, email2)
+}
+
+// CompareEmails compares two email addresses and returns the full score breakdown.
+//
+// Addresses of the same mailbox (see CanonicalizeEmail) score 1.0. Otherwise the local parts and
+// the domains are scored separately and combined with the configured weights:
+//   - the local parts with the configured local-part similarity, so "jahn" is close to "john"
+//   - the domains score 1.0 when equal and the configured typo weight when one is a slip away from
+//     the other, a popular provider ("gmial.com" and "gmail.com", see SuggestEmailDomain). Different
+//     domains score 0.0, however similar: the same local part at another domain is usually another
+//     mailbox, and "abc.com" and "abd.com" are two real domains as likely as one mistyped.
+//
+// When a domain is a typo of a provider, its local part is read under that provider's rules.
+// Both addresses are classified (see ClassifyEmail) and their risks returned with the score.
+func (m *NameMatcher) CompareEmails(email1, email2 string) EmailMatchResult {
+	result := m.compareEmails(email1, email2)
+	m.logger.LogAttrs(context.Background(), slog.LevelDebug, "email comparison",
+		slog.String("rule", string(result.Rule)),
+		slog.Float64("score", result.Score),
+		slog.Float64("local_score", result.LocalScore),
+		slog.Float64("domain_score", result.DomainScore),
+		slog.Bool("trusted", result.Trusted()),
+	)
+	return result
+}
+
+func (m *NameMatcher) compareEmails(email1, email2 string) EmailMatchResult {
+	config := m.config
+	result := EmailMatchResult{
+		Email1:     email1,
+		Email2:     email2,
+		Canonical1: CanonicalizeEmail(email1),
+		Canonical2: CanonicalizeEmail(email2),
+		Risk1:      m.ClassifyEmail(email1),
+		Risk2:      m.ClassifyEmail(email2),
+	}
+	switch {
+	case result.Canonical1 == "" && result.Canonical2 == "":
+		result.Rule, result.Score = RuleBothEmpty, 1.0
+		return result
+	case result.Canonical1 == "" || result.Canonical2 == "":
+		result.Rule, result.Score = RuleOneEmpty, 0.0
+		return result
+	case result.Canonical1 == result.Canonical2:
+		result.Rule, result.Score = RuleCanonicalEmailExact, 1.0
+		result.LocalScore, result.DomainScore = 1.0, 1.0
+		return result
+	}
+
+	address1, address2 := SplitEmail(result.Canonical1), SplitEmail(result.Canonical2)
+	result.Local1, result.Local2 = address1.Local, address2.Local
+	result.Domain1, result.Domain2 = address1.Domain, address2.Domain
+	result.DomainSuggestion1, _ = SuggestEmailDomain(result.Domain1)
+	result.DomainSuggestion2, _ = SuggestEmailDomain(result.Domain2)
+
+	switch {
+	case result.Domain1 == result.Domain2:
+		result.DomainScore = 1.0
+	case result.DomainSuggestion1 != "" && CanonicalizeEmail("x@"+result.DomainSuggestion1) == "x@"+result.Domain2:
+		// "john@gmial.com" and "john@gmail.com": read the local part as the provider would
+		result.DomainTypo, result.DomainScore = true, config.EmailDomainTypoWeight
+		result.Local1 = SplitEmail(CanonicalizeEmail(result.Local1 + "@" + result.DomainSuggestion1)).Local
+	case result.DomainSuggestion2 != "" && CanonicalizeEmail("x@"+result.DomainSuggestion2) == "x@"+result.Domain1:
+		result.DomainTypo, result.DomainScore = true, config.EmailDomainTypoWeight
+		result.Local2 = SplitEmail(CanonicalizeEmail(result.Local2 + "@" + result.DomainSuggestion2)).Local
+	}
+
+	if result.Local1 == result.Local2 {
+		result.LocalScore = 1.0
+	} else {
+		result.LocalScore = m.emailLocalSimilarity.Compare(result.Local1, result.Local2)
+	}
+	result.Rule = RuleEmailComponents
+	result.Score = weightedAverage(
+		[]float64{result.LocalScore, result.DomainScore},
+		[]float64{config.EmailLocalWeight, config.EmailDomainWeight},
+	)
+	m.traceDebug("email components",
+		slog.String("local1", result.Local1), slog.String("local2", result.Local2),
+		slog.String("domain1", result.Domain1), slog.String("domain2", result.Domain2),
+		slog.Bool("domain_typo", result.DomainTypo),
+	)
+	return result
+}
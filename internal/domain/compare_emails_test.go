@@ -0,0 +1,89 @@
+package domain
+
+import "testing"
+
+func TestCompareEmails(t *testing.T) {
+	result := CompareEmails("j.o.h.n+promo@gmail.com", "john@googlemail.com")
+	if result.Score != 1.0 || result.Rule != RuleCanonicalEmailExact {
+		t.Errorf("Expected the same Gmail mailbox to score 1.0, got %.2f (%s)", result.Score, result.Rule)
+	}
+
+	result = CompareEmails("john.smith+news@example.com", "john.smith@example.com")
+	if result.Score >= 1.0 || result.Rule != RuleEmailComponents {
+		t.Errorf("Expected plus tags to count for unknown providers, got %.2f (%s)", result.Score, result.Rule)
+	}
+
+	if score := CompareEmails("", "").Score; score != 1.0 {
+		t.Errorf("Expected empty emails to match, got %.2f", score)
+	}
+	if score := CompareEmails("john@example.com", "").Score; score != 0.0 {
+		t.Errorf("Expected a one-sided empty email not to match, got %.2f", score)
+	}
+}
+
+func TestCompareEmailsScoresLocalPartsAndDomains(t *testing.T) {
+	// The same local part at another domain is usually another mailbox, a local typo is not
+	otherDomain := CompareEmails("john@example.com", "john@exemple.org")
+	localTypo := CompareEmails("jahn@example.com", "john@example.com")
+	if otherDomain.LocalScore != 1.0 || otherDomain.DomainScore != 0.0 || otherDomain.DomainTypo {
+		t.Errorf("Expected identical local parts at different domains, got %+v", otherDomain)
+	}
+	if localTypo.DomainScore != 1.0 || localTypo.LocalScore >= 1.0 {
+		t.Errorf("Expected a local part typo at the same domain, got %+v", localTypo)
+	}
+	if otherDomain.Score >= localTypo.Score {
+		t.Errorf("Expected a local part typo (%.2f) to score above a different domain (%.2f)", localTypo.Score, otherDomain.Score)
+	}
+}
+
+func TestCompareEmailsDetectsDomainTypos(t *testing.T) {
+	result := CompareEmails("j.o.h.n@gmial.com", "john@gmail.com")
+	if !result.DomainTypo || result.DomainSuggestion1 != "gmail.com" || result.DomainScore != 0.9 {
+		t.Errorf("Expected 'gmial.com' to be a typo of 'gmail.com', got %+v", result)
+	}
+	if result.Local1 != "john" || result.LocalScore != 1.0 {
+		t.Errorf("Expected the local part to be read under Gmail rules, got '%s'", result.Local1)
+	}
+
+	for _, pair := range [][2]string{
+		{"john@gmx.de", "john@gmx.net"},
+		{"john@abc.com", "john@abd.com"},
+		{"john@box.net", "john@cox.net"},
+		{"john@email.com", "john@gmail.com"},
+	} {
+		if result := CompareEmails(pair[0], pair[1]); result.DomainTypo || result.DomainScore != 0.0 {
+			t.Errorf("Expected '%s' and '%s' to be at different domains, got %+v", pair[0], pair[1], result)
+		}
+	}
+}
+
+func TestSuggestEmailDomain(t *testing.T) {
+	tests := []struct{ domain, want string }{
+		{"gmial.com", "gmail.com"},
+		{"hotmail.con", "hotmail.com"},
+		{"yaho.com", "yahoo.com"},
+		{"outlok.com", "outlook.com"},
+		{"gmaill.com", "gmail.com"},
+		{"email.com", ""},
+		{"box.net", ""},
+		{"gmail.com", ""},
+		{"example.com", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := SuggestEmailDomain(tt.domain); got != tt.want {
+			t.Errorf("SuggestEmailDomain('%s') = '%s', want '%s'", tt.domain, got, tt.want)
+		}
+	}
+}
+
+func TestCompareEmailsKeepsProviderNamespacesApart(t *testing.T) {
+	for _, pair := range [][2]string{
+		{"john@hotmail.com", "john@outlook.com"},
+		{"john@live.com", "john@msn.com"},
+		{"john@fastmail.fm", "john@fastmail.com"},
+	} {
+		if result := CompareEmails(pair[0], pair[1]); result.Rule == RuleCanonicalEmailExact || result.Score >= 1.0 {
+			t.Errorf("Expected '%s' and '%s' to be different mailboxes, got %.2f (%s)", pair[0], pair[1], result.Score, result.Rule)
+		}
+	}
+}
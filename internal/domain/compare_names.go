@@ -1,99 +1,529 @@
 package domain
 
 import (
-	"fmt"
+	"context"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
 )
 
-// CompareNames compares two names using tokenized comparison with a hybrid approach
+// initialsOnlyMaxScore caps the score of names that agree in nothing but initials: "A" is the
+// initial of Alice, Anna and every other name starting with that letter
+const initialsOnlyMaxScore = 0.5
+
+// CompareNames compares two names using tokenized comparison with a hybrid approach.
+// The score is always in [0,1]: 1.0 means the names are equivalent and 0.0 means nothing matched.
 func CompareNames(name1, name2 string) float64 {
+	return CompareNamesDetailed(name1, name2).Score
+}
+
+// CompareNamesDetailed compares two names like CompareNames and returns the full score breakdown:
+// per-token alignments, Levenshtein similarities, phonetic codes and the rule that decided the score
+func CompareNamesDetailed(name1, name2 string) NameMatchResult {
+	return defaultNameMatcher.Compare(name1, name2)
+}
+
+// CompareNamesWithConfig compares two names using the weights and cut-offs of the given scoring config.
+// It builds a NameMatcher, with its encoders, patterns and dictionaries, on every call.
+//
+// Deprecated: build a matcher once with NewNameMatcher and call its Compare method.
+func CompareNamesWithConfig(name1, name2 string, config ScoringConfig) NameMatchResult {
+	return NewNameMatcher(config).Compare(name1, name2)
+}
+
+// Compare compares two names and returns the full score breakdown.
+//
+// Both names are parsed into components first (see Parse), so titles, suffixes and "Last, First"
+// order do not shift the comparison. The name score is the larger of two components, both in [0,1]:
+//   - the positional score, the weighted average of the given name and surname similarities
+//   - the token alignment, the weighted average score of the optimal one-to-one pairing of the
+//     tokens of both names (see alignTokens), so "Alexander Doe" is nearly contained in
+//     "Jonathan Alexander Doe" while a token repeated in one name ("John John") can only be
+//     paired once. Tokens are weighted by length and by their given/middle/surname role, and
+//     tokens left without a partner count against the score.
+//
+// Names that agree in nothing but initials ("A" and "Alice") score at most 0.5, and conflicting
+// generational suffixes ("Jr." vs "Sr.") reduce the score by the configured penalty.
+func (m *NameMatcher) Compare(name1, name2 string) NameMatchResult {
+	return m.CompareWithOptions(name1, name2, CompareOptions{})
+}
+
+// CompareOptions carries per-comparison hints about the names being compared
+type CompareOptions struct {
+	// Order1 and Order2 are the orders the names are expected in (see NameOrderForCulture)
+	Order1 NameOrder
+	Order2 NameOrder
+	// DoubleSurnames compares both names under the Spanish convention of a paternal and a maternal
+	// surname (see compareDoubleSurnames); every given name may then stand for the name
+	DoubleSurnames bool
+	// Channel is the input channel the names were captured through ("keyboard", "document-scan"),
+	// which selects the typo costs of the edit distance (see ScoringConfig.ChannelTypoCosts)
+	Channel string
+}
+
+// CompareOptionsForCulture returns the options for names of a culture given as a language tag:
+// its name order, and the double-surname rules for Spanish ("es", "es-CO")
+func CompareOptionsForCulture(culture string) CompareOptions {
+	order := NameOrderForCulture(culture)
+	return CompareOptions{Order1: order, Order2: order, DoubleSurnames: cultureLanguage(culture) == "es"}
+}
+
+// CompareWithOptions compares two names like Compare, parsing each name in its expected order.
+// With OrderInvariant enabled the first name is also read in the opposite order, and the order
+// that aligns the given names and surnames best is kept ("Nagy János" and "János Nagy").
+func (m *NameMatcher) CompareWithOptions(name1, name2 string, opts CompareOptions) NameMatchResult {
+	result := m.compare(name1, name2, opts)
+	m.logger.LogAttrs(context.Background(), slog.LevelDebug, "name comparison",
+		slog.String("rule", string(result.Rule)),
+		slog.Float64("score", result.Score),
+		slog.Float64("positional_score", result.PositionalScore),
+		slog.Float64("middle_score", result.MiddleScore),
+	)
+	return result
+}
+
+func (m *NameMatcher) compare(name1, name2 string, opts CompareOptions) NameMatchResult {
+	config := m.config
+	result := NameMatchResult{Name1: name1, Name2: name2, Channel: opts.Channel}
+
 	// Handle empty names explicitly
 	if name1 == "" && name2 == "" {
-		fmt.Printf("Both names are empty, returning perfect match score of 1.0\n")
-		return 1.0
+		result.Rule = RuleBothEmpty
+		result.Score = 1.0
+		return result
 	}
 
-	// Check for empty names and return a negative score for a no match
+	// An empty name never matches a non-empty one
 	if len(name1) == 0 || len(name2) == 0 {
-		fmt.Printf("One of the names is empty, returning score -1.0\n")
-		return -1.0
+		result.Rule = RuleOneEmpty
+		result.Score = 0.0
+		return result
 	}
 
-	// Normalize both names
-	normalized1 := NormalizeName(name1)
-	normalized2 := NormalizeName(name2)
+	// Names read by character recognition may carry digits in place of letters ("J0hn")
+	typoCosts := m.TypoCosts(opts.Channel)
+	name1, name2 = typoCosts.RepairDigits(name1), typoCosts.RepairDigits(name2)
 
-	// Step 1: Compare entire normalized names directly (to handle cases like "YukiMatsuda" vs "Yuki Matsuda")
-	if normalized1 == normalized2 {
-		fmt.Printf("Exact match for full names '%s' and '%s'\n", name1, name2)
-		return 1.0
+	// Normalize both names, romanizing non-Latin scripts. The original names and their scripts
+	// are kept in the result for display.
+	result.Script1, result.Script2 = NameScript(name1), NameScript(name2)
+	result.Normalized1 = m.Normalize(name1)
+	result.Normalized2 = m.Normalize(name2)
+	m.traceDebug("normalized names",
+		slog.String("name1", name1), slog.String("name2", name2),
+		slog.String("normalized1", result.Normalized1), slog.String("normalized2", result.Normalized2),
+	)
+
+	// Step 1: Compare entire normalized names directly, ignoring separators
+	// (to handle cases like "YukiMatsuda" vs "Yuki Matsuda")
+	if result.Normalized1 == result.Normalized2 || compactName(result.Normalized1) == compactName(result.Normalized2) {
+		result.Rule = RuleFullNormalizedExact
+		result.Score = 1.0
+		return result
 	}
 
-	tokens1 := TokenizeName(name1)
-	tokens2 := TokenizeName(name2)
+	// Romanization variants are only considered when one of the names is written in a CJK
+	// script, so Western names such as "Moore" and "More" are not declared equal
+	state := &comparisonState{
+		phoneticKeys:   map[string][]PhoneticCode{},
+		romanized:      isCJKScript(result.Script1) || isCJKScript(result.Script2),
+		doubleSurnames: opts.DoubleSurnames,
+		typoCosts:      typoCosts,
+	}
+	if state.romanized && sameRomanizedName(result.Normalized1, result.Normalized2) {
+		// "毛泽东" and "Mao Tse-tung": the names are different romanizations of the same name
+		result.Rule = RuleRomanizationExact
+		result.Score = 1.0
+		return result
+	}
 
-	// Check if token slices are empty to prevent index out of range errors
-	if len(tokens1) == 0 || len(tokens2) == 0 {
-		fmt.Printf("One of the tokenized names is empty, returning score 0.0\n")
-		return 0.0 // Handle empty token lists
+	parsed1, order1 := m.parseInOrder(name1, opts.Order1)
+	parsed2, order2 := m.parseInOrder(name2, opts.Order2)
+	if opts.DoubleSurnames {
+		parsed1, parsed2 = m.splitDoubleSurname(parsed1, order1), m.splitDoubleSurname(parsed2, order2)
 	}
+	result.Tokens1, result.Tokens2 = parsed1.Tokens(), parsed2.Tokens()
 
-	totalScore := 0.0
+	// Check if token slices are empty to prevent index out of range errors
+	if len(result.Tokens1) == 0 || len(result.Tokens2) == 0 {
+		result.Rule = RuleNoTokens
+		result.Score = 0.0 // Handle empty token lists
+		return result
+	}
 
-	// Ensure we compare first and last names properly by treating middle names as optional
-	firstName1 := tokens1[0]
-	lastName1 := tokens1[len(tokens1)-1]
+	// Compare the given names and the surnames component to component. In order-invariant mode
+	// the first name is also read in the opposite order, and the reading whose components align
+	// best with the second name is kept.
+	firstName, lastName, positional := m.comparePositional(parsed1, parsed2, state)
+	if config.OrderInvariant && !opts.DoubleSurnames && len(result.Tokens1) > 1 {
+		reversed, reversedOrder := m.parseInOrder(name1, order1.Reversed())
+		if f, l, p := m.comparePositional(reversed, parsed2, state); p > positional {
+			parsed1, order1 = reversed, reversedOrder
+			firstName, lastName, positional = f, l, p
+			result.OrderSwapped = true
+		}
+	}
+	result.Parsed1, result.Parsed2 = parsed1, parsed2
+	result.Order1, result.Order2 = order1, order2
+	m.traceDebug("tokenized names",
+		slog.Any("tokens1", result.Tokens1), slog.Any("tokens2", result.Tokens2),
+		slog.Any("parsed1", parsed1), slog.Any("parsed2", parsed2),
+		slog.Bool("order_swapped", result.OrderSwapped),
+	)
+	result.FirstName = &firstName
+	result.LastName = &lastName
+	result.FirstNameScore = firstName.Score
+	result.LastNameScore = lastName.Score
+	result.PositionalScore = positional
 
-	firstName2 := tokens2[0]
-	lastName2 := tokens2[len(tokens2)-1]
+	if firstName.ExactMatch && lastName.ExactMatch {
+		// If both first and last names are exact matches, treat it as a perfect match (score = 1.0)
+		result.Rule = RuleFirstLastExact
+		result.Score = 1.0
+	} else if firstName.NicknameMatch && lastName.ExactMatch {
+		// "Bill Gates" and "William Gates": the given name is a known variant and the surname matches
+		result.Rule = RuleNicknameMatch
+		result.Score = result.PositionalScore
+	} else if firstName.InitialMatch && lastName.ExactMatch {
+		// "J. Smith" and "John Smith": the given name is abbreviated and the surname matches
+		result.Rule = RuleInitialMatch
+		result.Score = result.PositionalScore
+	} else if opts.DoubleSurnames {
+		// The surname comparison already weighs dropped and swapped surnames
+		result.Rule = RuleDoubleSurname
+		result.Score = result.PositionalScore
+	} else {
+		result.MiddleTokens, result.Unmatched1, result.Unmatched2, result.MiddleScore =
+			m.alignTokens(parsed1.matchTokens(), parsed2.matchTokens(), state)
+		if result.PositionalScore >= result.MiddleScore {
+			result.Rule = RuleFirstLastPositional
+			result.Score = result.PositionalScore
+		} else {
+			result.Rule = RuleMiddleTokenSweep
+			result.Score = result.MiddleScore
+		}
+	}
 
-	firstNameScore, isFirstNameExactMatch := compareToken(firstName1, firstName2)
+	// "A" and "Alice": an initial is only evidence when another token agrees with it
+	if onlyInitialsAgree(append([]TokenComparison{firstName, lastName}, result.MiddleTokens...), config.LevenshteinCutoff) {
+		result.Rule = RuleInitialsOnly
+		result.Score = min(result.Score, initialsOnlyMaxScore)
+	}
 
-	lastNameScore, isLastNameExactMatch := compareToken(lastName1, lastName2)
+	// "John Doe Jr." and "John Doe Sr." are usually different people
+	if suffixesConflict(parsed1.Suffix, parsed2.Suffix) {
+		result.SuffixConflict = true
+		result.Score *= 1.0 - config.SuffixMismatchPenalty
+	}
+	return result
+}
 
-	// If both first and last names are exact matches, treat it as a perfect match (score = 1.0)
-	if isFirstNameExactMatch && isLastNameExactMatch {
-		totalScore = 1.0
-		fmt.Printf("Exact match for both first and last names, setting total score to 1.0\n")
+// comparePositional compares the given names and the surnames of two parsed names and returns both
+// comparisons with their weighted average (see evidenceAverage)
+func (m *NameMatcher) comparePositional(parsed1, parsed2 ParsedName, state *comparisonState) (TokenComparison, TokenComparison, float64) {
+	firstName := m.compareGivenNames(parsed1, parsed2, state)
+	var lastName TokenComparison
+	if state.doubleSurnames {
+		lastName = m.compareDoubleSurnames(parsed1, parsed2, state)
 	} else {
-		// Names in between the first and the last name
-		fullTokenScore := 0.0
-		if len(tokens1) > 2 || len(tokens2) > 2 {
-			for _, token1 := range tokens1 {
-				bestScore := 0.0
-				for _, token2 := range tokens2 {
-					score, _ := compareToken(token1, token2)
-					if bestScore < score {
-						bestScore = score
-					}
-				}
-				fullTokenScore += bestScore
+		lastName = m.compareToken(surnameOrGiven(parsed1), surnameOrGiven(parsed2), state)
+	}
+	positional := evidenceAverage(
+		[]float64{firstName.Score, lastName.Score},
+		[]float64{m.config.FirstNameWeight, m.config.LastNameWeight},
+		[]float64{
+			m.frequencyWeight(firstName.Token1, firstName.Token2),
+			m.frequencyWeight(lastName.Token1, lastName.Token2),
+		},
+	)
+	return firstName, lastName, positional
+}
+
+// compareGivenNames compares the given names of two parsed names, letting a nickname stand in for
+// the given name it belongs to. A name without a given name is represented by its surname. Under
+// the double-surname rules the names before the surnames are all given names, and any of them
+// may stand for the name ("Ferney Perez" for "Brayan Ferney Perez Moreno").
+func (m *NameMatcher) compareGivenNames(parsed1, parsed2 ParsedName, state *comparisonState) TokenComparison {
+	candidates := func(parsed ParsedName) []string {
+		names := []string{givenOrSurname(parsed)}
+		if state.doubleSurnames && parsed.Given != "" {
+			names = append(names, parsed.Middle...)
+		}
+		if parsed.Nickname != "" {
+			names = append(names, parsed.Nickname)
+		}
+		return names
+	}
+	candidates1, candidates2 := candidates(parsed1), candidates(parsed2)
+
+	var best TokenComparison
+	for i, candidate1 := range candidates1 {
+		for j, candidate2 := range candidates2 {
+			comparison := m.compareToken(candidate1, candidate2, state)
+			if (i == 0 && j == 0) || best.Score < comparison.Score {
+				best = comparison
 			}
 		}
+	}
+	return best
+}
+
+// alignTokens pairs the tokens of both names one to one so that the total weighted score is highest
+// (see assignTokens) and returns the pairs, the tokens left without a partner and the weighted average
+// score (see evidenceAverage). Pairs are weighted by token length and role. A token left without a partner counts as a
+// mismatch for the configured fraction of its weight: a missing surname weighs more than a missing
+// given or middle name, which are often dropped in favour of a preferred name.
+func (m *NameMatcher) alignTokens(tokens1, tokens2 []nameToken, state *comparisonState) ([]TokenComparison, []string, []string, float64) {
+	comparisons := make([][]TokenComparison, len(tokens1))
+	weighted := make([][]float64, len(tokens1))
+	for i, token1 := range tokens1 {
+		comparisons[i] = make([]TokenComparison, len(tokens2))
+		weighted[i] = make([]float64, len(tokens2))
+		for j, token2 := range tokens2 {
+			comparisons[i][j] = m.compareToken(token1.text, token2.text, state)
+			weighted[i][j] = comparisons[i][j].Score * m.pairWeight(token1, token2) * m.frequencyWeight(token1.text, token2.text)
+		}
+	}
 
-		fmt.Printf("!!!---!!! Scores fist: '%.2f', last: '%.2f', fullToken: '%.2f'", firstNameScore, lastNameScore, fullTokenScore)
-		// Total score is based on first name, last name, and middle name (if present)
-		totalScore = firstNameScore + lastNameScore + fullTokenScore
+	var pairs []TokenComparison
+	var unmatched1, unmatched2 []string
+	var scores, weights, frequencies []float64
+	paired2 := make([]bool, len(tokens2))
+	for i, j := range assignTokens(weighted) {
+		if j < 0 {
+			unmatched1 = append(unmatched1, tokens1[i].text)
+			scores, weights, frequencies = append(scores, 0), append(weights, m.unmatchedWeight(tokens1[i])), append(frequencies, 1)
+			continue
+		}
+		paired2[j] = true
+		pairs = append(pairs, comparisons[i][j])
+		scores = append(scores, comparisons[i][j].Score)
+		weights = append(weights, m.pairWeight(tokens1[i], tokens2[j]))
+		frequencies = append(frequencies, m.frequencyWeight(tokens1[i].text, tokens2[j].text))
+	}
+	for j, paired := range paired2 {
+		if !paired {
+			unmatched2 = append(unmatched2, tokens2[j].text)
+			scores, weights, frequencies = append(scores, 0), append(weights, m.unmatchedWeight(tokens2[j])), append(frequencies, 1)
+		}
 	}
+	return pairs, unmatched1, unmatched2, evidenceAverage(scores, weights, frequencies)
+}
 
-	fmt.Printf("Final total score: %.2f\n", totalScore)
-	return totalScore
+// pairWeight returns the weight of a pair of aligned tokens: the length of the shorter token times
+// the average weight of their roles
+func (m *NameMatcher) pairWeight(token1, token2 nameToken) float64 {
+	length := float64(min(utf8.RuneCountInString(token1.text), utf8.RuneCountInString(token2.text)))
+	return length * (m.roleWeight(token1.role) + m.roleWeight(token2.role)) / 2
 }
 
-func compareToken(token1 string, token2 string) (float64, bool) {
-	// Compare first names
-	primary1, alternate1 := PhoneticMatch(token1)
-	primary2, alternate2 := PhoneticMatch(token2)
-	TokenScore := LevenshteinSimilarity(token1, token2)
-	fmt.Printf("Comparing first names '%s' -> '%s', Phonetic: (%s, %s) vs (%s, %s)\n", token1, token2, primary1, alternate1, primary2, alternate2)
+// unmatchedWeight returns the weight a token without a partner counts against the alignment
+func (m *NameMatcher) unmatchedWeight(token nameToken) float64 {
+	penalty := m.config.UnmatchedMiddlePenalty
+	if token.role == RoleSurname {
+		penalty = m.config.UnmatchedSurnamePenalty
+	}
+	return float64(utf8.RuneCountInString(token.text)) * m.roleWeight(token.role) * penalty
+}
 
-	isFirstNameExactMatch := false
-	if primary1 == primary2 || alternate1 == alternate2 || primary1 == alternate2 || alternate1 == primary2 {
-		if TokenScore >= 0.8 {
-			TokenScore = 0.9
-			isFirstNameExactMatch = true
+// frequencyWeight returns the average frequency weight of the tokens, 1 when the matcher has no
+// token frequency model. Multi-word surnames weigh as much as their rarest word.
+func (m *NameMatcher) frequencyWeight(tokens ...string) float64 {
+	if m.frequencies == nil {
+		return 1.0
+	}
+	total := 0.0
+	for _, token := range tokens {
+		weight := 0.0
+		for _, word := range strings.Fields(token) {
+			weight = max(weight, m.frequencies.Weight(word))
+		}
+		total += weight
+	}
+	return total / float64(len(tokens))
+}
+
+// compareToken compares two tokens and explains the comparison. The score is the similarity of the
+// configured algorithm, raised to the phonetic boost when any configured phonetic encoder agrees on
+// the tokens and their Levenshtein similarity reaches the configured cut-off; ExactMatch is set in that case.
+// Otherwise tokens the nickname dictionary declares variants of each other score the configured nickname
+// weight, and an initial and a name starting with that letter score the configured initial weight.
+// When a CJK name is compared, romanizations of the same syllables ("zhang" and "chang") are exact matches.
+// When the input channel has typo costs, they weigh the Levenshtein similarity, and the configured
+// similarity too when it is Levenshtein.
+func (m *NameMatcher) compareToken(token1, token2 string, state *comparisonState) TokenComparison {
+	config := m.config
+	comparison := TokenComparison{
+		Token1:      token1,
+		Token2:      token2,
+		Algorithm:   m.similarityName,
+		Similarity:  m.similarity.Compare(token1, token2),
+		Levenshtein: LevenshteinSimilarity(token1, token2),
+	}
+	if state.typoCosts != nil {
+		// Typos expected from the input channel cost less than other edits
+		comparison.Levenshtein = WeightedLevenshtein{Costs: state.typoCosts}.Compare(token1, token2)
+		if m.similarityName == SimilarityLevenshtein {
+			comparison.Algorithm, comparison.Similarity = weightedLevenshteinAlgorithm, comparison.Levenshtein
 		}
 	}
-	TokenScore *= 0.4 // Apply weight for first names
-	fmt.Printf("Token score after weighting: %.2f\n", TokenScore)
-	return TokenScore, isFirstNameExactMatch
+	codes1, codes2 := m.phoneticKeys(token1, state.phoneticKeys), m.phoneticKeys(token2, state.phoneticKeys)
+	for i, encoder := range m.phoneticEncoders {
+		phonetic := PhoneticComparison{Encoder: encoder.name, Code1: codes1[i], Code2: codes2[i]}
+		phonetic.Match = phoneticCodesMatch(phonetic.Code1, phonetic.Code2)
+		comparison.Phonetic = append(comparison.Phonetic, phonetic)
+		comparison.PhoneticMatch = comparison.PhoneticMatch || phonetic.Match
+	}
+
+	TokenScore := comparison.Similarity
+	if comparison.PhoneticMatch && comparison.Levenshtein >= config.LevenshteinCutoff {
+		TokenScore = max(TokenScore, config.PhoneticBoost)
+		comparison.ExactMatch = true
+	}
+	if !comparison.ExactMatch && state.romanized && romanizationEquivalent(token1, token2) {
+		TokenScore = max(TokenScore, config.PhoneticBoost)
+		comparison.ExactMatch = true
+		comparison.RomanizationMatch = true
+	}
+	if !comparison.ExactMatch && config.NicknameWeight > 0 && m.nicknames.Equivalent(token1, token2) {
+		TokenScore = max(TokenScore, config.NicknameWeight)
+		comparison.NicknameMatch = true
+	}
+	if !comparison.ExactMatch && config.InitialWeight > 0 && isInitialOf(token1, token2) {
+		TokenScore = max(TokenScore, config.InitialWeight)
+		comparison.InitialMatch = true
+	}
+	comparison.Score = TokenScore
+	if m.tracing() {
+		m.traceDebug("token comparison",
+			slog.String("token1", token1), slog.String("token2", token2),
+			slog.String("algorithm", comparison.Algorithm), slog.Float64("similarity", comparison.Similarity),
+			slog.Float64("levenshtein", comparison.Levenshtein), slog.Any("phonetic", comparison.Phonetic),
+			slog.Bool("exact_match", comparison.ExactMatch), slog.Bool("nickname_match", comparison.NicknameMatch),
+			slog.Bool("romanization_match", comparison.RomanizationMatch), slog.Bool("initial_match", comparison.InitialMatch), slog.Float64("score", comparison.Score),
+		)
+	}
+	return comparison
+}
+
+// phoneticKeys returns the keys of every configured phonetic encoder for token, encoding it only once per cache
+func (m *NameMatcher) phoneticKeys(token string, cache map[string][]PhoneticCode) []PhoneticCode {
+	if codes, ok := cache[token]; ok {
+		return codes
+	}
+	normalized := m.Normalize(token)
+	codes := make([]PhoneticCode, len(m.phoneticEncoders))
+	for i, encoder := range m.phoneticEncoders {
+		codes[i].Primary, codes[i].Alternate = encoder.encoder.Encode(normalized)
+	}
+	cache[token] = codes
+	return codes
+}
+
+// comparisonState is shared by the token comparisons of one name comparison
+type comparisonState struct {
+	// phoneticKeys caches the phonetic keys of every token, computed once and reused across token pairs
+	phoneticKeys map[string][]PhoneticCode
+	// romanized is set when one of the names is written in a CJK script
+	romanized bool
+	// doubleSurnames is set when the names are compared under the Spanish double-surname rules
+	doubleSurnames bool
+	// typoCosts prices the typos of the input channel of the names, nil for plain edit distances
+	typoCosts *SubstitutionCosts
+}
+
+// isCJKScript reports whether a script returned by NameScript is Han, kana or Hangul
+func isCJKScript(script string) bool {
+	switch script {
+	case "Han", "Hiragana", "Katakana", "Hangul":
+		return true
+	}
+	return false
+}
+
+// roleWeight returns the configured weight of a name component
+func (m *NameMatcher) roleWeight(role NameRole) float64 {
+	switch role {
+	case RoleGiven:
+		return m.config.FirstNameWeight
+	case RoleSurname:
+		return m.config.LastNameWeight
+	default:
+		return m.config.MiddleNameWeight
+	}
+}
+
+// givenOrSurname returns the given name, or the surname without particles when there is no given name
+func givenOrSurname(parsed ParsedName) string {
+	if parsed.Given != "" {
+		return parsed.Given
+	}
+	return surnameCore(parsed.Surname)
+}
+
+// surnameOrGiven returns the surname without particles, or the given name when there is no surname
+func surnameOrGiven(parsed ParsedName) string {
+	if len(parsed.Surname) > 0 {
+		return surnameCore(parsed.Surname)
+	}
+	return parsed.Given
+}
+
+// isInitialOf reports whether one token is a single-letter initial and the other a longer name
+// starting with that letter. Clustered initials ("J.R.R.") are split into one token per letter
+// by the tokenizer, so each of them is matched against its own name.
+func isInitialOf(token1, token2 string) bool {
+	if utf8.RuneCountInString(token2) == 1 {
+		token1, token2 = token2, token1
+	}
+	return utf8.RuneCountInString(token1) == 1 && utf8.RuneCountInString(token2) > 1 && strings.HasPrefix(token2, token1)
+}
+
+// evidenceAverage returns the weighted average of scores in which only the agreeing share of every
+// score is scaled by its frequency weight (see TokenFrequencies): a match on a common token is weak
+// evidence, but a mismatch on one counts against the names in full. Frequency weights can therefore
+// only lower the average, never raise it. With frequency weights of 1 it is weightedAverage.
+func evidenceAverage(scores, weights, frequencies []float64) float64 {
+	agreement, disagreement := 0.0, 0.0
+	for i, score := range scores {
+		agreement += score * weights[i] * frequencies[i]
+		disagreement += (1 - score) * weights[i]
+	}
+	if agreement+disagreement == 0 {
+		return 0.0
+	}
+	return agreement / (agreement + disagreement)
+}
+
+// onlyInitialsAgree reports whether some of the comparisons are initial matches and none of the
+// others agrees: an exact, phonetic or nickname match or a similarity reaching the cut-off
+func onlyInitialsAgree(comparisons []TokenComparison, cutoff float64) bool {
+	initials := false
+	for _, comparison := range comparisons {
+		switch {
+		case comparison.InitialMatch:
+			initials = true
+		case comparison.ExactMatch || comparison.NicknameMatch || comparison.Score >= cutoff:
+			return false
+		}
+	}
+	return initials
+}
+
+// weightedAverage returns the weighted average of scores, or 0.0 when all weights are zero
+func weightedAverage(scores, weights []float64) float64 {
+	total, totalWeight := 0.0, 0.0
+	for i, score := range scores {
+		total += score * weights[i]
+		totalWeight += weights[i]
+	}
+	if totalWeight == 0 {
+		return 0.0
+	}
+	return total / totalWeight
+}
+
+// compactName removes spaces and hyphens from a normalized name
+func compactName(normalized string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(normalized)
 }
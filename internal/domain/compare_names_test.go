@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestCompareNamesDetailedMatchesCompareNames(t *testing.T) {
+	pairs := [][2]string{
+		{"John Doe", "John Doe"},
+		{"Perez", "Peres"},
+		{"Brayan Ferney Perez Moreno", "Ferney Perez"},
+		{"Alice", "Bob"},
+	}
+	for _, pair := range pairs {
+		result := CompareNamesDetailed(pair[0], pair[1])
+		if score := CompareNames(pair[0], pair[1]); result.Score != score {
+			t.Errorf("CompareNamesDetailed('%s', '%s') score = %.2f, CompareNames = %.2f", pair[0], pair[1], result.Score, score)
+		}
+	}
+}
+
+func TestCompareNamesDetailedRules(t *testing.T) {
+	tests := []struct {
+		name1, name2 string
+		rule         MatchRule
+	}{
+		{"", "", RuleBothEmpty},
+		{"John Doe", "", RuleOneEmpty},
+		{"José da Silva", "Jose da Silva", RuleFullNormalizedExact},
+		{"!!!", "Brayan Perez", RuleNoTokens},
+		{"John Alexander Doe", "John Doe", RuleFirstLastExact},
+		{"Brayan Ferney Perez Moreno", "Ferney Perez", RuleMiddleTokenSweep},
+	}
+	for _, tt := range tests {
+		if got := CompareNamesDetailed(tt.name1, tt.name2).Rule; got != tt.rule {
+			t.Errorf("'%s' vs '%s': expected rule %s, got %s", tt.name1, tt.name2, tt.rule, got)
+		}
+	}
+}
+
+func TestCompareNamesDetailedTokenBreakdown(t *testing.T) {
+	result := CompareNamesDetailed("Perez Brayan", "Peres Brayan")
+
+	if result.FirstName == nil || result.LastName == nil {
+		t.Fatalf("Expected first and last name comparisons, got %+v", result)
+	}
+	if result.FirstName.Token1 != "perez" || result.FirstName.Token2 != "peres" {
+		t.Errorf("Expected first name alignment 'perez' -> 'peres', got '%s' -> '%s'", result.FirstName.Token1, result.FirstName.Token2)
+	}
+	if len(result.FirstName.Phonetic) != 1 || result.FirstName.Phonetic[0].Code1.Primary == "" || !result.FirstName.PhoneticMatch {
+		t.Errorf("Expected a phonetic match with Metaphone codes, got %+v", *result.FirstName)
+	}
+	if result.FirstName.Levenshtein != 0.8 {
+		t.Errorf("Expected Levenshtein similarity 0.80 for 'perez' -> 'peres', got %.2f", result.FirstName.Levenshtein)
+	}
+	if !result.LastName.ExactMatch {
+		t.Errorf("Expected exact last name match for 'brayan', got %+v", *result.LastName)
+	}
+}
+
+func TestCompareNamesScoreIsBounded(t *testing.T) {
+	pairs := [][2]string{
+		{"Jonathan Alexander Michael Robert William Doe", "Jonathan Alexander Michael Robert William Dough"},
+		{"Jonathan Alexander Michael Robert William Doe", "Alexander Doe"},
+		{"Brayan Ferney Perez Moreno", "Brayan F. Perez"},
+		{"John Doe", ""},
+		{"!!!", "Brayan Perez"},
+		{"Alice", "Charles"},
+	}
+	for _, pair := range pairs {
+		result := CompareNamesDetailed(pair[0], pair[1])
+		scores := []float64{result.Score, result.FirstNameScore, result.LastNameScore, result.PositionalScore, result.MiddleScore}
+		for _, score := range scores {
+			if score < 0.0 || score > 1.0 {
+				t.Errorf("'%s' vs '%s': expected all scores in [0,1], got %+v", pair[0], pair[1], result)
+			}
+		}
+	}
+}
+
+func TestCompareNamesPositionalScore(t *testing.T) {
+	result := CompareNamesDetailed("Bryan Paris", "Brayan Perez")
+
+	if result.Rule != RuleFirstLastPositional {
+		t.Errorf("Expected rule %s, got %s", RuleFirstLastPositional, result.Rule)
+	}
+	want := (result.FirstNameScore + result.LastNameScore) / 2
+	if result.PositionalScore != want || result.Score != want {
+		t.Errorf("Expected positional score %.2f as the final score, got %+v", want, result)
+	}
+}
+
+func TestCompareNamesInitialMatch(t *testing.T) {
+	result := CompareNamesDetailed("J. Smith", "John Smith")
+	if result.Rule != RuleInitialMatch || !result.FirstName.InitialMatch {
+		t.Errorf("Expected rule %s for 'J. Smith' vs 'John Smith', got %+v", RuleInitialMatch, result)
+	}
+	want := (DefaultScoringConfig().InitialWeight + 1.0) / 2
+	if result.Score != want {
+		t.Errorf("Expected score %.3f for 'J. Smith' vs 'John Smith', got %.3f", want, result.Score)
+	}
+
+	result = CompareNamesDetailed("Brayan F. Perez", "Brayan Ferney Perez Moreno")
+	initialMatched := false
+	for _, token := range result.MiddleTokens {
+		if token.Token1 == "f" && token.Token2 == "ferney" && token.InitialMatch {
+			initialMatched = true
+		}
+	}
+	if !initialMatched || result.Score < 0.9 {
+		t.Errorf("Expected 'f' to match 'ferney' as an initial, got %+v", result)
+	}
+
+	if score := CompareNames("J.R.R. Tolkien", "John Ronald Reuel Tolkien"); score < 0.9 {
+		t.Errorf("Expected clustered initials 'J.R.R.' to match 'John Ronald Reuel', got %.2f", score)
+	}
+	if result := CompareNamesDetailed("F. Perez", "G. Perez"); result.FirstName.InitialMatch || result.FirstNameScore != 0 {
+		t.Errorf("Expected different initials not to match, got %+v", result)
+	}
+
+	// An initial alone is no evidence
+	for _, pair := range [][2]string{{"A", "Alice"}, {"A. Smith", "Alice Jones"}, {"J. S.", "John Smith"}} {
+		if score := CompareNames(pair[0], pair[1]); score >= 0.8 {
+			t.Errorf("Expected '%s' and '%s' not to match on initials alone, got %.2f", pair[0], pair[1], score)
+		}
+	}
+	if result := CompareNamesDetailed("A", "Alice"); result.Rule != RuleInitialsOnly || result.Score > initialsOnlyMaxScore {
+		t.Errorf("Expected rule %s for 'A' vs 'Alice', got %s with %.2f", RuleInitialsOnly, result.Rule, result.Score)
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeNationalID reduces a government ID number to its uppercase letters and digits, so
+// "123.456.789-0" and "1234567890" are the same number. Leading zeros of numeric IDs are dropped,
+// as spreadsheets and numeric columns drop them too. Placeholders instead of a number ("N/A",
+// "000-00-0000"), without any digit but zero, normalize to "".
+func NormalizeNationalID(id string) string {
+	var sb strings.Builder
+	for _, r := range RemoveDiacritics(id) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	normalized := sb.String()
+	if !strings.ContainsAny(normalized, "123456789") {
+		return ""
+	}
+	if strings.Trim(normalized, "0123456789") == "" {
+		return strings.TrimLeft(normalized, "0")
+	}
+	return normalized
+}
+
+// CompareNationalIDs compares two government ID numbers (see NormalizeNationalID). IDs are issued to
+// one person each and carry check digits, so different numbers score 0.0 however close they are.
+// Placeholders are no ID at all and score 0.0 under RuleUnusableValue.
+func CompareNationalIDs(id1, id2 string) FieldMatch {
+	match := FieldMatch{
+		Value1:      id1,
+		Value2:      id2,
+		Normalized1: NormalizeNationalID(id1),
+		Normalized2: NormalizeNationalID(id2),
+		Rule:        RuleNationalIDMismatch,
+	}
+	switch {
+	case match.Normalized1 == "" || match.Normalized2 == "":
+		match.Rule = RuleUnusableValue
+	case match.Normalized1 == match.Normalized2:
+		match.Rule, match.Score = RuleNationalIDExact, 1.0
+	}
+	return match
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"context"
+	"github.com/agnivade/levenshtein"
+	"log/slog"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OrganizationMatchResult is the structured outcome of an organization name comparison.
+// All scores are in [0,1]; Score is the component selected by Rule, less the legal form penalty.
+type OrganizationMatchResult struct {
+	Name1             string            `json:"name1"`
+	Name2             string            `json:"name2"`
+	Normalized1       string            `json:"normalized1"`
+	Normalized2       string            `json:"normalized2"`
+	Parsed1           OrganizationName  `json:"parsed1"`
+	Parsed2           OrganizationName  `json:"parsed2"`
+	Tokens            []TokenComparison `json:"tokens,omitempty"`
+	Unmatched1        []string          `json:"unmatched1,omitempty"`
+	Unmatched2        []string          `json:"unmatched2,omitempty"`
+	TokenSetScore     float64           `json:"token_set_score"`
+	ContainmentScore  float64           `json:"containment_score"`
+	LegalFormConflict bool              `json:"legal_form_conflict"`
+	Rule              MatchRule         `json:"rule"`
+	Score             float64           `json:"score"`
+}
+
+// CompareOrganizationNames compares two organization names with the default matcher (see CompareOrganizations)
+func CompareOrganizationNames(name1, name2 string) float64 {
+	return defaultNameMatcher.CompareOrganizations(name1, name2).Score
+}
+
+// CompareOrganizations compares two organization names ("Acme Corp." and "ACME Corporation Ltd")
+// and returns the full score breakdown.
+//
+// Both names are parsed first (see ParseOrganizationName), so legal forms, stopwords and
+// abbreviations do not count. Names whose words are equal once spaces are removed ("FedEx" and
+// "Fed Ex") match exactly, and a single word spelling the initials of the other name scores the
+// configured acronym weight. Otherwise the tokens are aligned one to one and scored as sets: the
+// average of their overlap with both names and with the shorter name, so "Acme" is nearly
+// contained in "Acme Widgets" while word order does not matter. Legal forms of different kinds of
+// entity ("LLC" and "LP") reduce the score by the configured penalty.
+func (m *NameMatcher) CompareOrganizations(name1, name2 string) OrganizationMatchResult {
+	result := m.compareOrganizations(name1, name2)
+	m.logger.LogAttrs(context.Background(), slog.LevelDebug, "organization comparison",
+		slog.String("rule", string(result.Rule)),
+		slog.Float64("score", result.Score),
+		slog.Float64("token_set_score", result.TokenSetScore),
+	)
+	return result
+}
+
+func (m *NameMatcher) compareOrganizations(name1, name2 string) OrganizationMatchResult {
+	result := OrganizationMatchResult{
+		Name1:       name1,
+		Name2:       name2,
+		Normalized1: NormalizeOrganizationName(name1),
+		Normalized2: NormalizeOrganizationName(name2),
+	}
+	if result.Normalized1 == "" && result.Normalized2 == "" {
+		result.Rule, result.Score = RuleBothEmpty, 1.0
+		return result
+	}
+	if result.Normalized1 == "" || result.Normalized2 == "" {
+		result.Rule, result.Score = RuleOneEmpty, 0.0
+		return result
+	}
+
+	result.Parsed1, result.Parsed2 = ParseOrganizationName(name1), ParseOrganizationName(name2)
+	tokens1, tokens2 := result.Parsed1.Tokens, result.Parsed2.Tokens
+	m.traceDebug("organization normalization",
+		slog.String("normalized1", result.Normalized1), slog.String("normalized2", result.Normalized2),
+		slog.Any("parsed1", result.Parsed1), slog.Any("parsed2", result.Parsed2),
+	)
+
+	result.Tokens, result.Unmatched1, result.Unmatched2, result.TokenSetScore, result.ContainmentScore =
+		m.compareTokenSets(tokens1, tokens2)
+	switch {
+	case strings.Join(tokens1, "") == strings.Join(tokens2, ""):
+		result.Rule, result.Score = RuleOrganizationExact, 1.0
+	case m.config.AcronymWeight > 0 && (len(tokens1) == 1 && acronymOf(tokens1[0], tokens2) ||
+		len(tokens2) == 1 && acronymOf(tokens2[0], tokens1)):
+		result.Rule = RuleAcronym
+		result.Score = max(m.config.AcronymWeight, (result.TokenSetScore+result.ContainmentScore)/2)
+	default:
+		result.Rule, result.Score = RuleTokenSet, (result.TokenSetScore+result.ContainmentScore)/2
+	}
+
+	if legalFormsConflict(result.Parsed1.LegalForms, result.Parsed2.LegalForms) {
+		result.LegalFormConflict = true
+		result.Score *= 1 - m.config.LegalFormMismatchPenalty
+	}
+	return result
+}
+
+// compareTokenSets aligns the tokens of two organization names one to one (see assignTokens) and
+// returns the aligned pairs, the tokens left without a partner, and two scores weighted by token
+// length: the overlap with both names, and the overlap with the shorter name
+func (m *NameMatcher) compareTokenSets(tokens1, tokens2 []string) (pairs []TokenComparison, unmatched1, unmatched2 []string, setScore, containment float64) {
+	comparisons := make([][]TokenComparison, len(tokens1))
+	scores := make([][]float64, len(tokens1))
+	for i, token1 := range tokens1 {
+		comparisons[i] = make([]TokenComparison, len(tokens2))
+		scores[i] = make([]float64, len(tokens2))
+		for j, token2 := range tokens2 {
+			comparisons[i][j] = m.compareOrganizationTokens(token1, token2)
+			scores[i][j] = comparisons[i][j].Score * float64(tokenLength(token1)+tokenLength(token2))
+		}
+	}
+
+	matched2 := make([]bool, len(tokens2))
+	overlap1, overlap2 := 0.0, 0.0
+	for i, j := range assignTokens(scores) {
+		if j < 0 {
+			unmatched1 = append(unmatched1, tokens1[i])
+			continue
+		}
+		matched2[j] = true
+		pairs = append(pairs, comparisons[i][j])
+		overlap1 += comparisons[i][j].Score * float64(tokenLength(tokens1[i]))
+		overlap2 += comparisons[i][j].Score * float64(tokenLength(tokens2[j]))
+	}
+	for j, token := range tokens2 {
+		if !matched2[j] {
+			unmatched2 = append(unmatched2, token)
+		}
+	}
+
+	length1, length2 := float64(tokensLength(tokens1)), float64(tokensLength(tokens2))
+	setScore = (overlap1 + overlap2) / (length1 + length2)
+	if length1 <= length2 {
+		containment = overlap1 / length1
+	} else {
+		containment = overlap2 / length2
+	}
+	return pairs, unmatched1, unmatched2, setScore, containment
+}
+
+// compareOrganizationTokens compares two words of organization names, already normalized, with the
+// configured similarity. Words with digits must be equal: "Studio 54" and "Studio 45" are different
+// businesses however close the numbers are written.
+func (m *NameMatcher) compareOrganizationTokens(token1, token2 string) TokenComparison {
+	comparison := TokenComparison{
+		Token1:    token1,
+		Token2:    token2,
+		Algorithm: m.similarityName,
+		// LevenshteinSimilarity would normalize the words again and drop their digits
+		Levenshtein: editSimilarity(levenshtein.ComputeDistance(token1, token2), tokenLength(token1), tokenLength(token2)),
+		ExactMatch:  token1 == token2,
+	}
+	comparison.Similarity = comparison.Levenshtein
+	if m.similarityName != SimilarityLevenshtein {
+		comparison.Similarity = m.similarity.Compare(token1, token2)
+	}
+	switch {
+	case comparison.ExactMatch:
+		comparison.Score = 1.0
+	case strings.ContainsFunc(token1, unicode.IsDigit) || strings.ContainsFunc(token2, unicode.IsDigit):
+		comparison.Score = 0.0
+	default:
+		comparison.Score = comparison.Similarity
+	}
+	return comparison
+}
+
+// tokenLength returns the number of runes of a token
+func tokenLength(token string) int {
+	return utf8.RuneCountInString(token)
+}
+
+// tokensLength returns the total number of runes of tokens
+func tokensLength(tokens []string) int {
+	total := 0
+	for _, token := range tokens {
+		total += tokenLength(token)
+	}
+	return total
+}
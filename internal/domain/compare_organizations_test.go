@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOrganizationName(t *testing.T) {
+	tests := []struct {
+		name string
+		want OrganizationName
+	}{
+		{"Acme Corp.", OrganizationName{Tokens: []string{"acme"}, LegalForms: []string{"corp"}}},
+		{"ACME Corporation Ltd", OrganizationName{Tokens: []string{"acme"}, LegalForms: []string{"corp", "ltd"}}},
+		{"Grupo Bimbo, S.A.B. de C.V.", OrganizationName{Tokens: []string{"grupo", "bimbo"}, LegalForms: []string{"sa de cv"}}},
+		{"Comercial Andina Ltda.", OrganizationName{Tokens: []string{"comercial", "andina"}, LegalForms: []string{"ltda"}}},
+		{"Müller & Söhne GmbH & Co. KG", OrganizationName{Tokens: []string{"muller", "sohne"}, LegalForms: []string{"gmbh", "co", "kg"}}},
+		{"Sony Group Kabushiki Kaisha", OrganizationName{Tokens: []string{"sony", "group"}, LegalForms: []string{"kk"}}},
+		{"The Intl Bank of Commerce", OrganizationName{Tokens: []string{"international", "bank", "commerce"}}},
+		{"The Limited", OrganizationName{Tokens: []string{"limited"}}},
+	}
+	for _, tt := range tests {
+		if got := ParseOrganizationName(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseOrganizationName('%s') = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompareOrganizationNames(t *testing.T) {
+	matches := [][2]string{
+		{"Acme Corp.", "ACME Corporation Ltd"},
+		{"Siemens AG", "Siemens Aktiengesellschaft"},
+		{"Intl Paper Co", "International Paper Company"},
+		{"Johnson & Johnson", "Johnson and Johnson"},
+		{"FedEx", "Fed Ex Corp"},
+		{"IBM", "International Business Machines Corp"},
+		{"Nestlé S.A.", "Nestle SA"},
+	}
+	for _, pair := range matches {
+		if score := CompareOrganizationNames(pair[0], pair[1]); score < 0.9 {
+			t.Errorf("'%s' vs '%s': expected a score of at least 0.90, got %.2f", pair[0], pair[1], score)
+		}
+	}
+
+	mismatches := [][2]string{
+		{"Apple Inc", "Microsoft Corp"},
+		{"American Airlines", "American Express"},
+		{"Studio 54 LLC", "Studio 45 LLC"},
+		{"Route 66 Inc", "Route 67 Inc"},
+	}
+	for _, pair := range mismatches {
+		if score := CompareOrganizationNames(pair[0], pair[1]); score >= 0.8 {
+			t.Errorf("'%s' vs '%s': expected a score below 0.80, got %.2f", pair[0], pair[1], score)
+		}
+	}
+}
+
+func TestCompareOrganizationsExplainsTheScore(t *testing.T) {
+	result := DefaultNameMatcher().CompareOrganizations("Acme Widgets", "Acme")
+	if result.Rule != RuleTokenSet || result.ContainmentScore != 1 || len(result.Unmatched1) != 1 || result.Unmatched1[0] != "widgets" {
+		t.Errorf("Expected 'Acme' to be contained in 'Acme Widgets', got %+v", result)
+	}
+	if result.Score >= 1 || result.Score <= result.TokenSetScore {
+		t.Errorf("Expected a partial score above the token set score, got %.2f", result.Score)
+	}
+
+	result = DefaultNameMatcher().CompareOrganizations("IBM", "International Business Machines")
+	if result.Rule != RuleAcronym {
+		t.Errorf("Expected rule %s, got %s", RuleAcronym, result.Rule)
+	}
+}
+
+func TestCompareOrganizationsPenalizesConflictingLegalForms(t *testing.T) {
+	result := DefaultNameMatcher().CompareOrganizations("Acme LLC", "Acme LP")
+	if !result.LegalFormConflict || result.Score != 0.9 {
+		t.Errorf("Expected conflicting legal forms to cost 10%%, got %+v", result)
+	}
+	for _, pair := range [][2]string{{"Acme Corp", "Acme Ltd"}, {"Acme Co", "Acme LP"}, {"Acme", "Acme LLC"}} {
+		if result := DefaultNameMatcher().CompareOrganizations(pair[0], pair[1]); result.LegalFormConflict || result.Score != 1 {
+			t.Errorf("'%s' vs '%s': expected no legal form conflict, got %+v", pair[0], pair[1], result)
+		}
+	}
+}
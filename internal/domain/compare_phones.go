@@ -0,0 +1,94 @@
+package domain
+
+import "strings"
+
+// minPhoneDigits is the fewest digits a national number must have to be matched against the end
+// of an international one; shorter numbers are local extensions or fragments
+const minPhoneDigits = 7
+
+// Scores of phone numbers that are not written the same
+const (
+	// phoneNationalNumberScore is the score of a national number that ends an international one
+	// ("0612345678" and "+33 6 12 34 56 78"); the country it was dialled from is unknown
+	phoneNationalNumberScore = 0.95
+	// phoneTypoScore is the score of numbers one mistyped or swapped digit apart, as likely
+	// another line as a typo
+	phoneTypoScore = 0.5
+)
+
+// PhoneNumber is a phone number reduced to its digits
+type PhoneNumber struct {
+	// Digits are the digits of the number, without international or trunk prefix
+	Digits string `json:"digits"`
+	// International is set when the number starts with a country code ("+57", "0057")
+	International bool `json:"international"`
+}
+
+// String returns the number as +digits when international, as digits otherwise
+func (p PhoneNumber) String() string {
+	if p.International {
+		return "+" + p.Digits
+	}
+	return p.Digits
+}
+
+// NormalizePhone reduces a phone number to its digits. A leading "+" or "00" marks an international
+// number and is dropped, as is the trunk prefix "0" of national numbers ("(0)20 7946 0958" is
+// "2079460958"). Extensions ("x12", "ext. 12", ";12") and any other character are ignored.
+func NormalizePhone(phone string) PhoneNumber {
+	phone = strings.ToLower(strings.TrimSpace(phone))
+	if i := strings.IndexAny(phone, "x;#"); i >= 0 {
+		phone = phone[:i]
+	}
+	if i := strings.Index(phone, "ext"); i >= 0 {
+		phone = phone[:i]
+	}
+
+	var number PhoneNumber
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	number.Digits = digits.String()
+	switch {
+	case strings.HasPrefix(phone, "+"):
+		number.International = true
+	case strings.HasPrefix(number.Digits, "00"):
+		number.International, number.Digits = true, number.Digits[2:]
+	default:
+		number.Digits = strings.TrimPrefix(number.Digits, "0")
+	}
+	return number
+}
+
+// ComparePhones compares two phone numbers by their digits (see NormalizePhone).
+//
+// Numbers with the same digits score 1.0. A national number the international one ends with
+// ("612345678" and "+33612345678") scores a little less, as the country code cannot be checked.
+// Numbers of the same length one substituted or swapped digit apart are likely typos but as likely
+// another line, and score half; any other numbers score 0.0.
+func ComparePhones(phone1, phone2 string) FieldMatch {
+	number1, number2 := NormalizePhone(phone1), NormalizePhone(phone2)
+	match := FieldMatch{
+		Value1:      phone1,
+		Value2:      phone2,
+		Normalized1: number1.String(),
+		Normalized2: number2.String(),
+		Rule:        RulePhoneMismatch,
+	}
+	digits1, digits2 := number1.Digits, number2.Digits
+	switch {
+	case digits1 == "" || digits2 == "":
+	case digits1 == digits2:
+		match.Rule, match.Score = RulePhoneExact, 1.0
+	case number1.International != number2.International && len(digits1) >= minPhoneDigits && len(digits2) >= minPhoneDigits &&
+		(number1.International && strings.HasSuffix(digits1, digits2) || number2.International && strings.HasSuffix(digits2, digits1)):
+		match.Rule, match.Score = RulePhoneNationalNumber, phoneNationalNumberScore
+	case len(digits1) == len(digits2) && len(digits1) >= minPhoneDigits &&
+		damerauLevenshteinDistance([]rune(digits1), []rune(digits2)) == 1:
+		match.Rule, match.Score = RulePhoneTypo, phoneTypoScore
+	}
+	return match
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  PhoneNumber
+	}{
+		{"+57 (300) 123-4567", PhoneNumber{Digits: "573001234567", International: true}},
+		{"0057 300 123 4567", PhoneNumber{Digits: "573001234567", International: true}},
+		{"(0)20 7946 0958", PhoneNumber{Digits: "2079460958"}},
+		{"555.123.4567 ext. 12", PhoneNumber{Digits: "5551234567"}},
+		{"555-123-4567 x12", PhoneNumber{Digits: "5551234567"}},
+	}
+	for _, test := range tests {
+		if got := NormalizePhone(test.phone); got != test.want {
+			t.Errorf("NormalizePhone(%q) = %+v, want %+v", test.phone, got, test.want)
+		}
+	}
+}
+
+func TestComparePhones(t *testing.T) {
+	tests := []struct {
+		phone1, phone2 string
+		rule           MatchRule
+		score          float64
+	}{
+		{"+33 6 12 34 56 78", "0033612345678", RulePhoneExact, 1.0},
+		{"+33 6 12 34 56 78", "06 12 34 56 78", RulePhoneNationalNumber, phoneNationalNumberScore},
+		{"555-123-4567", "555-123-4576", RulePhoneTypo, phoneTypoScore},
+		{"555-123-4567", "555-987-6543", RulePhoneMismatch, 0.0},
+		{"+1 555 123 4567", "+44 555 123 4567", RulePhoneMismatch, 0.0},
+		{"1234", "+33 1234", RulePhoneMismatch, 0.0},
+	}
+	for _, test := range tests {
+		if match := ComparePhones(test.phone1, test.phone2); match.Rule != test.rule || match.Score != test.score {
+			t.Errorf("ComparePhones(%q, %q) = %.2f (%s), want %.2f (%s)", test.phone1, test.phone2, match.Score, match.Rule, test.score, test.rule)
+		}
+	}
+}
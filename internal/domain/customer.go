@@ -1,22 +1,84 @@
 package domain
 
-// Customer represents a customer entity in the system
+// Customer represents a customer entity in the system. Only Name and Email are always known; the
+// other attributes are optional and left empty when a record does not carry them.
 type Customer struct {
-	Name  string
-	Email string
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	DateOfBirth string `json:"date_of_birth,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Address     string `json:"address,omitempty"`
+	NationalID  string `json:"national_id,omitempty"`
 }
 
+// CustomerField names an attribute of a customer record
+type CustomerField string
+
+const (
+	FieldName        CustomerField = "name"
+	FieldEmail       CustomerField = "email"
+	FieldDateOfBirth CustomerField = "date_of_birth"
+	FieldPhone       CustomerField = "phone"
+	FieldAddress     CustomerField = "address"
+	FieldNationalID  CustomerField = "national_id"
+)
+
+// CustomerFields are the attributes of a customer record, in the order they are compared
+var CustomerFields = []CustomerField{FieldName, FieldEmail, FieldDateOfBirth, FieldPhone, FieldAddress, FieldNationalID}
+
 // NewCustomer creates a new Customer instance
 func NewCustomer(name, email string) *Customer {
 	return &Customer{Name: name, Email: email}
 }
 
+// Field returns the value of an attribute of the customer, or "" for an unknown field
+func (c *Customer) Field(field CustomerField) string {
+	switch field {
+	case FieldName:
+		return c.Name
+	case FieldEmail:
+		return c.Email
+	case FieldDateOfBirth:
+		return c.DateOfBirth
+	case FieldPhone:
+		return c.Phone
+	case FieldAddress:
+		return c.Address
+	case FieldNationalID:
+		return c.NationalID
+	}
+	return ""
+}
+
 // MatchName compares two names using tokenized comparison
 func (c *Customer) MatchName(otherName string) float64 {
 	return CompareNames(c.Name, otherName)
 }
 
-// MatchEmail compares two emails using Levenshtein similarity
+// MatchNameWithConfig compares two names using the given scoring config. It builds a matcher on every call.
+//
+// Deprecated: build a matcher once with NewNameMatcher and use MatchNameWith.
+func (c *Customer) MatchNameWithConfig(otherName string, config ScoringConfig) float64 {
+	return c.MatchNameWith(NewNameMatcher(config), otherName)
+}
+
+// MatchNameWith compares two names using the given name matcher
+func (c *Customer) MatchNameWith(matcher *NameMatcher, otherName string) float64 {
+	return matcher.Compare(c.Name, otherName).Score
+}
+
+// MatchEmail compares two emails by mailbox, local part and domain (see CompareEmails)
 func (c *Customer) MatchEmail(otherEmail string) float64 {
-	return LevenshteinSimilarity(c.Email, otherEmail)
+	return CompareEmails(c.Email, otherEmail).Score
+}
+
+// MatchEmailWith compares two emails using the given matcher
+func (c *Customer) MatchEmailWith(matcher *NameMatcher, otherEmail string) float64 {
+	return matcher.CompareEmails(c.Email, otherEmail).Score
+}
+
+// MatchWith compares every attribute both customers carry using the given matcher (see
+// NameMatcher.CompareCustomers)
+func (c *Customer) MatchWith(matcher *NameMatcher, other *Customer) CustomerMatchResult {
+	return matcher.CompareCustomers(c, other)
 }
@@ -135,7 +135,10 @@ func TestCompletelyDifferentNames(t *testing.T) {
 func TestOneSidedEmptyName(t *testing.T) {
 	customer := NewCustomer("John Doe", "john@example.com")
 	score := customer.MatchName("")
-	assertNoMatchWithLogging(t, score, 0.0, "One-sided empty name 'John Doe' vs ''")
+	fmt.Printf("One-sided empty name 'John Doe' vs '': Got score = %.2f\n", score)
+	if score != 0.0 {
+		t.Errorf("Expected score 0.0 for a one-sided empty name, got %.2f", score)
+	}
 }
 
 func TestVeryLongNames(t *testing.T) {
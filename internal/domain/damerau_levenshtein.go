@@ -0,0 +1,55 @@
+package domain
+
+// DamerauLevenshtein is the edit-distance similarity that counts insertions, deletions,
+// substitutions and transpositions of adjacent runes as single edits, even when the
+// transposed runes are edited again ("ca" -> "abc" costs 2)
+type DamerauLevenshtein struct{}
+
+// Compare returns the Damerau-Levenshtein similarity of a and b
+func (DamerauLevenshtein) Compare(a, b string) float64 {
+	r1, r2 := []rune(a), []rune(b)
+	return editSimilarity(damerauLevenshteinDistance(r1, r2), len(r1), len(r2))
+}
+
+// damerauLevenshteinDistance computes the unrestricted Damerau-Levenshtein distance
+// (Lowrance-Wagner algorithm)
+func damerauLevenshteinDistance(r1, r2 []rune) int {
+	infinity := len(r1) + len(r2)
+	lastRow := map[rune]int{}
+
+	// d is offset by one row and column holding the infinity sentinel
+	d := make([][]int, len(r1)+2)
+	for i := range d {
+		d[i] = make([]int, len(r2)+2)
+	}
+	d[0][0] = infinity
+	for i := 0; i <= len(r1); i++ {
+		d[i+1][0] = infinity
+		d[i+1][1] = i
+	}
+	for j := 0; j <= len(r2); j++ {
+		d[0][j+1] = infinity
+		d[1][j+1] = j
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		lastMatchColumn := 0
+		for j := 1; j <= len(r2); j++ {
+			k := lastRow[r2[j-1]]
+			l := lastMatchColumn
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+				lastMatchColumn = j
+			}
+			d[i+1][j+1] = min(
+				d[i][j]+cost,              // substitution
+				d[i+1][j]+1,               // insertion
+				d[i][j+1]+1,               // deletion
+				d[k][l]+(i-k-1)+1+(j-l-1), // transposition
+			)
+		}
+		lastRow[r1[i-1]] = i
+	}
+	return d[len(r1)+1][len(r2)+1]
+}
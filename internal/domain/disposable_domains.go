@@ -0,0 +1,78 @@
+package domain
+
+import "strings"
+
+// bundledDisposableDomains are domains of well-known disposable and temporary mailbox services,
+// whose addresses anyone can read and nobody keeps
+var bundledDisposableDomains = []string{
+	"10minutemail.com", "10minutemail.net", "20minutemail.com", "33mail.com", "burnermail.io",
+	"discard.email", "dispostable.com", "emailfake.com", "emailondeck.com", "fakeinbox.com",
+	"getairmail.com", "getnada.com", "grr.la", "guerrillamail.biz", "guerrillamail.com",
+	"guerrillamail.de", "guerrillamail.net", "guerrillamail.org", "guerrillamailblock.com",
+	"harakirimail.com", "inboxkitten.com", "jetable.org", "mailcatch.com", "maildrop.cc",
+	"mailinator.com", "mailinator.net", "mailinator2.com", "mailnesia.com", "mailpoof.com",
+	"mintemail.com", "mohmal.com", "moakt.com", "mytemp.email", "pokemail.net", "sharklasers.com",
+	"spam4.me", "spambox.us", "spamgourmet.com", "temp-mail.io", "temp-mail.org", "tempail.com",
+	"tempinbox.com", "tempmail.net", "tempmailo.com", "tempr.email", "throwawaymail.com",
+	"trashmail.com", "trashmail.de", "trashmail.net", "yopmail.com", "yopmail.fr", "yopmail.net",
+}
+
+// DisposableDomains is a list of disposable email domains. A domain is disposable when it or any
+// domain it is a subdomain of is listed. A DisposableDomains is immutable and safe for concurrent use.
+type DisposableDomains struct {
+	domains map[string]bool
+}
+
+// NewDisposableDomains creates a list from domain names, converted to ASCII (see DomainToASCII)
+func NewDisposableDomains(domains []string) *DisposableDomains {
+	return (&DisposableDomains{}).Extend(domains)
+}
+
+// DefaultDisposableDomains returns the bundled list of well-known disposable mailbox services
+func DefaultDisposableDomains() *DisposableDomains {
+	return defaultDisposableDomains
+}
+
+// defaultDisposableDomains is built once from the bundled domains
+var defaultDisposableDomains = NewDisposableDomains(bundledDisposableDomains)
+
+// Extend returns a new list with the domains of d plus the given domains
+func (d *DisposableDomains) Extend(domains []string) *DisposableDomains {
+	extended := &DisposableDomains{domains: make(map[string]bool, len(d.domains)+len(domains))}
+	for domain := range d.domains {
+		extended.domains[domain] = true
+	}
+	for _, domain := range domains {
+		if domain = DomainToASCII(strings.TrimSpace(domain)); domain != "" {
+			extended.domains[domain] = true
+		}
+	}
+	return extended
+}
+
+// Contains reports whether an ASCII domain or one of its parent domains is listed
+// ("inbox.mailinator.com" is disposable when "mailinator.com" is)
+func (d *DisposableDomains) Contains(domain string) bool {
+	if d == nil {
+		return false
+	}
+	for domain != "" {
+		if d.domains[domain] {
+			return true
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return false
+}
+
+// Len returns the number of listed domains
+func (d *DisposableDomains) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.domains)
+}
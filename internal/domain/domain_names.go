@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// DomainToASCII converts an internationalized domain name to its ASCII form, the form it has in
+// DNS, with the IDNA lookup profile: labels are mapped (lowercased, normalized) and labels with
+// non-ASCII characters are Punycode-encoded ("Bücher.de" is "xn--bcher-kva.de"). Ideographic full
+// stops separate labels like ASCII dots, and a trailing dot is dropped. Domains IDNA rejects are
+// still mapped as far as possible, so that the characters at fault can be reported.
+func DomainToASCII(domain string) string {
+	ascii, _ := idna.Lookup.ToASCII(domain)
+	return strings.TrimSuffix(ascii, ".")
+}
+
+// DomainToUnicode converts a domain name to its Unicode form for display, decoding Punycode
+// labels ("xn--bcher-kva.de" is "bücher.de"). Labels that are not valid Punycode are kept.
+func DomainToUnicode(domain string) string {
+	unicode, _ := idna.Lookup.ToUnicode(domain)
+	return strings.TrimSuffix(unicode, ".")
+}
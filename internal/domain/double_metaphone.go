@@ -0,0 +1,579 @@
+package domain
+
+import "strings"
+
+// DoubleMetaphone is Lawrence Philips' Double Metaphone encoder. Keys are truncated to four
+// characters; the alternate key is empty when it equals the primary key.
+type DoubleMetaphone struct{}
+
+// Encode returns the Double Metaphone primary and alternate keys of name
+func (DoubleMetaphone) Encode(name string) (string, string) {
+	word := string(asciiLetters(name))
+	if word == "" {
+		return "", ""
+	}
+
+	dm := &doubleMetaphoneState{
+		word:   word + "     ",
+		length: len(word),
+		last:   len(word) - 1,
+	}
+	dm.slavoGermanic = strings.Contains(word, "W") || strings.Contains(word, "K") ||
+		strings.Contains(word, "CZ") || strings.Contains(word, "WITZ")
+	dm.encode()
+
+	primary, alternate := dm.primary.String(), dm.alternate.String()
+	if len(primary) > 4 {
+		primary = primary[:4]
+	}
+	if len(alternate) > 4 {
+		alternate = alternate[:4]
+	}
+	if alternate == primary {
+		alternate = ""
+	}
+	return primary, alternate
+}
+
+// doubleMetaphoneState holds the word being encoded and the keys built so far
+type doubleMetaphoneState struct {
+	word          string
+	length        int
+	last          int
+	slavoGermanic bool
+	primary       strings.Builder
+	alternate     strings.Builder
+}
+
+// at returns the character at position i, or 0 outside the padded word
+func (dm *doubleMetaphoneState) at(i int) byte {
+	if i < 0 || i >= len(dm.word) {
+		return 0
+	}
+	return dm.word[i]
+}
+
+// stringAt reports whether any of the candidates appears at position start
+func (dm *doubleMetaphoneState) stringAt(start, length int, candidates ...string) bool {
+	if start < 0 || start+length > len(dm.word) {
+		return false
+	}
+	target := dm.word[start : start+length]
+	for _, candidate := range candidates {
+		if candidate == target {
+			return true
+		}
+	}
+	return false
+}
+
+// isVowel reports whether the character at position i is a vowel
+func (dm *doubleMetaphoneState) isVowel(i int) bool {
+	return strings.IndexByte("AEIOUY", dm.at(i)) >= 0
+}
+
+// add appends main to both keys
+func (dm *doubleMetaphoneState) add(main string) {
+	dm.primary.WriteString(main)
+	dm.alternate.WriteString(main)
+}
+
+// addAlternate appends main to the primary key and alternate to the alternate key
+func (dm *doubleMetaphoneState) addAlternate(main, alternate string) {
+	dm.primary.WriteString(main)
+	dm.alternate.WriteString(alternate)
+}
+
+// germanic reports whether the word starts like a Germanic name ("VAN ", "VON ", "SCH")
+func (dm *doubleMetaphoneState) germanic() bool {
+	return dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH")
+}
+
+func (dm *doubleMetaphoneState) encode() {
+	current := 0
+
+	// Skip these when at the start of the word
+	if dm.stringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		current++
+	}
+	// Initial 'X' is pronounced 'Z', e.g. 'Xavier'
+	if dm.at(0) == 'X' {
+		dm.add("S")
+		current++
+	}
+
+	for current < dm.length && (dm.primary.Len() < 4 || dm.alternate.Len() < 4) {
+		switch dm.at(current) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			// All initial vowels map to 'A'
+			if current == 0 {
+				dm.add("A")
+			}
+			current++
+		case 'B':
+			dm.add("P")
+			current += dm.skipDouble(current, 'B')
+		case 'C':
+			current = dm.encodeC(current)
+		case 'D':
+			switch {
+			case dm.stringAt(current, 2, "DG") && dm.stringAt(current+2, 1, "I", "E", "Y"):
+				// e.g. 'edge'
+				dm.add("J")
+				current += 3
+			case dm.stringAt(current, 2, "DG"):
+				// e.g. 'edgar'
+				dm.add("TK")
+				current += 2
+			case dm.stringAt(current, 2, "DT", "DD"):
+				dm.add("T")
+				current += 2
+			default:
+				dm.add("T")
+				current++
+			}
+		case 'F':
+			dm.add("F")
+			current += dm.skipDouble(current, 'F')
+		case 'G':
+			current = dm.encodeG(current)
+		case 'H':
+			// Only keep if first & before vowel or between 2 vowels
+			if (current == 0 || dm.isVowel(current-1)) && dm.isVowel(current+1) {
+				dm.add("H")
+				current += 2
+			} else {
+				current++
+			}
+		case 'J':
+			current = dm.encodeJ(current)
+		case 'K':
+			dm.add("K")
+			current += dm.skipDouble(current, 'K')
+		case 'L':
+			if dm.at(current+1) == 'L' {
+				// Spanish, e.g. 'cabrillo', 'gallegos'
+				if (current == dm.length-3 && dm.stringAt(current-1, 4, "ILLO", "ILLA", "ALLE")) ||
+					((dm.stringAt(dm.last-1, 2, "AS", "OS") || dm.stringAt(dm.last, 1, "A", "O")) && dm.stringAt(current-1, 4, "ALLE")) {
+					dm.addAlternate("L", "")
+					current += 2
+					continue
+				}
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("L")
+		case 'M':
+			if (dm.stringAt(current-1, 3, "UMB") && (current+1 == dm.last || dm.stringAt(current+2, 2, "ER"))) || dm.at(current+1) == 'M' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("M")
+		case 'N':
+			dm.add("N")
+			current += dm.skipDouble(current, 'N')
+		case 'P':
+			if dm.at(current+1) == 'H' {
+				dm.add("F")
+				current += 2
+				continue
+			}
+			// Also account for "campbell", "raspberry"
+			if dm.stringAt(current+1, 1, "P", "B") {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("P")
+		case 'Q':
+			dm.add("K")
+			current += dm.skipDouble(current, 'Q')
+		case 'R':
+			// French, e.g. 'rogier', but exclude 'hochmeier'
+			if current == dm.last && !dm.slavoGermanic && dm.stringAt(current-2, 2, "IE") && !dm.stringAt(current-4, 2, "ME", "MA") {
+				dm.addAlternate("", "R")
+			} else {
+				dm.add("R")
+			}
+			current += dm.skipDouble(current, 'R')
+		case 'S':
+			current = dm.encodeS(current)
+		case 'T':
+			current = dm.encodeT(current)
+		case 'V':
+			dm.add("F")
+			current += dm.skipDouble(current, 'V')
+		case 'W':
+			current = dm.encodeW(current)
+		case 'X':
+			// French, e.g. 'breaux'
+			if !(current == dm.last && (dm.stringAt(current-3, 3, "IAU", "EAU") || dm.stringAt(current-2, 2, "AU", "OU"))) {
+				dm.add("KS")
+			}
+			if dm.stringAt(current+1, 1, "C", "X") {
+				current += 2
+			} else {
+				current++
+			}
+		case 'Z':
+			// Chinese pinyin, e.g. 'zhao'
+			if dm.at(current+1) == 'H' {
+				dm.add("J")
+				current += 2
+				continue
+			}
+			if dm.stringAt(current+1, 2, "ZO", "ZI", "ZA") || (dm.slavoGermanic && current > 0 && dm.at(current-1) != 'T') {
+				dm.addAlternate("S", "TS")
+			} else {
+				dm.add("S")
+			}
+			current += dm.skipDouble(current, 'Z')
+		default:
+			current++
+		}
+	}
+}
+
+// skipDouble returns how far to advance past the letter at current, skipping a doubled letter c
+func (dm *doubleMetaphoneState) skipDouble(current int, c byte) int {
+	if dm.at(current+1) == c {
+		return 2
+	}
+	return 1
+}
+
+func (dm *doubleMetaphoneState) encodeC(current int) int {
+	// Various Germanic
+	if current > 1 && !dm.isVowel(current-2) && dm.stringAt(current-1, 3, "ACH") &&
+		dm.at(current+2) != 'I' && (dm.at(current+2) != 'E' || dm.stringAt(current-2, 6, "BACHER", "MACHER")) {
+		dm.add("K")
+		return current + 2
+	}
+	// Special case 'caesar'
+	if current == 0 && dm.stringAt(current, 6, "CAESAR") {
+		dm.add("S")
+		return current + 2
+	}
+	// Italian 'chianti'
+	if dm.stringAt(current, 4, "CHIA") {
+		dm.add("K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CH") {
+		// Find 'michael'
+		if current > 0 && dm.stringAt(current, 4, "CHAE") {
+			dm.addAlternate("K", "X")
+			return current + 2
+		}
+		// Greek roots, e.g. 'chemistry', 'chorus'
+		if current == 0 && (dm.stringAt(current+1, 5, "HARAC", "HARIS") || dm.stringAt(current+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+			!dm.stringAt(0, 5, "CHORE") {
+			dm.add("K")
+			return current + 2
+		}
+		// Germanic, Greek, or otherwise 'ch' for 'kh' sound
+		if dm.germanic() ||
+			// 'architect' but not 'arch', 'orchestra', 'orchid'
+			dm.stringAt(current-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+			dm.stringAt(current+2, 1, "T", "S") ||
+			((dm.stringAt(current-1, 1, "A", "O", "U", "E") || current == 0) &&
+				// e.g. 'wachtler', 'wechsler', but not 'tichner'
+				dm.stringAt(current+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ")) {
+			dm.add("K")
+		} else if current > 0 {
+			if dm.stringAt(0, 2, "MC") {
+				// e.g. 'McHugh'
+				dm.add("K")
+			} else {
+				dm.addAlternate("X", "K")
+			}
+		} else {
+			dm.add("X")
+		}
+		return current + 2
+	}
+	// e.g. 'czerny'
+	if dm.stringAt(current, 2, "CZ") && !dm.stringAt(current-2, 4, "WICZ") {
+		dm.addAlternate("S", "X")
+		return current + 2
+	}
+	// e.g. 'focaccia'
+	if dm.stringAt(current+1, 3, "CIA") {
+		dm.add("X")
+		return current + 3
+	}
+	// Double 'C', but not if e.g. 'McClellan'
+	if dm.stringAt(current, 2, "CC") && !(current == 1 && dm.at(0) == 'M') {
+		// 'bellocchio' but not 'bacchus'
+		if dm.stringAt(current+2, 1, "I", "E", "H") && !dm.stringAt(current+2, 2, "HU") {
+			if (current == 1 && dm.at(current-1) == 'A') || dm.stringAt(current-1, 5, "UCCEE", "UCCES") {
+				// 'accident', 'accede', 'succeed'
+				dm.add("KS")
+			} else {
+				// 'bacci', 'bertucci', other Italian
+				dm.add("X")
+			}
+			return current + 3
+		}
+		// Pierce's rule
+		dm.add("K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CK", "CG", "CQ") {
+		dm.add("K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CI", "CE", "CY") {
+		// Italian vs. English
+		if dm.stringAt(current, 3, "CIO", "CIE", "CIA") {
+			dm.addAlternate("S", "X")
+		} else {
+			dm.add("S")
+		}
+		return current + 2
+	}
+
+	dm.add("K")
+	// Names sent in as 'mac caffrey', 'mac gregor'
+	switch {
+	case dm.stringAt(current+1, 2, " C", " Q", " G"):
+		return current + 3
+	case dm.stringAt(current+1, 1, "C", "K", "Q") && !dm.stringAt(current+1, 2, "CE", "CI"):
+		return current + 2
+	default:
+		return current + 1
+	}
+}
+
+func (dm *doubleMetaphoneState) encodeG(current int) int {
+	if dm.at(current+1) == 'H' {
+		if current > 0 && !dm.isVowel(current-1) {
+			dm.add("K")
+			return current + 2
+		}
+		// 'ghislane', 'ghiradelli'
+		if current == 0 {
+			if dm.at(current+2) == 'I' {
+				dm.add("J")
+			} else {
+				dm.add("K")
+			}
+			return current + 2
+		}
+		// Parker's rule (with some further refinements), e.g. 'hugh', 'bough', 'broughton'
+		if (current > 1 && dm.stringAt(current-2, 1, "B", "H", "D")) ||
+			(current > 2 && dm.stringAt(current-3, 1, "B", "H", "D")) ||
+			(current > 3 && dm.stringAt(current-4, 1, "B", "H")) {
+			return current + 2
+		}
+		// e.g. 'laugh', 'McLaughlin', 'cough', 'gough', 'rough', 'tough'
+		if current > 2 && dm.at(current-1) == 'U' && dm.stringAt(current-3, 1, "C", "G", "L", "R", "T") {
+			dm.add("F")
+		} else if current > 0 && dm.at(current-1) != 'I' {
+			dm.add("K")
+		}
+		return current + 2
+	}
+
+	if dm.at(current+1) == 'N' {
+		if current == 1 && dm.isVowel(0) && !dm.slavoGermanic {
+			dm.addAlternate("KN", "N")
+		} else if !dm.stringAt(current+2, 2, "EY") && dm.at(current+1) != 'Y' && !dm.slavoGermanic {
+			// Not e.g. 'cagney'
+			dm.addAlternate("N", "KN")
+		} else {
+			dm.add("KN")
+		}
+		return current + 2
+	}
+
+	// 'tagliaro'
+	if dm.stringAt(current+1, 2, "LI") && !dm.slavoGermanic {
+		dm.addAlternate("KL", "L")
+		return current + 2
+	}
+
+	// -ges-, -gep-, -gel-, -gie- at beginning
+	if current == 0 && (dm.at(current+1) == 'Y' ||
+		dm.stringAt(current+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+		dm.addAlternate("K", "J")
+		return current + 2
+	}
+
+	// -ger-, -gy-
+	if (dm.stringAt(current+1, 2, "ER") || dm.at(current+1) == 'Y') &&
+		!dm.stringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!dm.stringAt(current-1, 1, "E", "I") && !dm.stringAt(current-1, 3, "RGY", "OGY") {
+		dm.addAlternate("K", "J")
+		return current + 2
+	}
+
+	// Italian, e.g. 'biaggi'
+	if dm.stringAt(current+1, 1, "E", "I", "Y") || dm.stringAt(current-1, 4, "AGGI", "OGGI") {
+		if dm.germanic() || dm.stringAt(current+1, 2, "ET") {
+			// Obvious Germanic
+			dm.add("K")
+		} else if dm.stringAt(current+1, 4, "IER ") {
+			// Always soft if French ending
+			dm.add("J")
+		} else {
+			dm.addAlternate("J", "K")
+		}
+		return current + 2
+	}
+
+	dm.add("K")
+	return current + dm.skipDouble(current, 'G')
+}
+
+func (dm *doubleMetaphoneState) encodeJ(current int) int {
+	// Obvious Spanish, 'jose', 'san jacinto'
+	if dm.stringAt(current, 4, "JOSE") || dm.stringAt(0, 4, "SAN ") {
+		if (current == 0 && dm.at(current+4) == ' ') || dm.stringAt(0, 4, "SAN ") {
+			dm.add("H")
+		} else {
+			dm.addAlternate("J", "H")
+		}
+		return current + 1
+	}
+
+	if current == 0 {
+		// Yankelovich/Jankelowicz
+		dm.addAlternate("J", "A")
+	} else if dm.isVowel(current-1) && !dm.slavoGermanic && (dm.at(current+1) == 'A' || dm.at(current+1) == 'O') {
+		// Spanish pronunciation of e.g. 'bajador'
+		dm.addAlternate("J", "H")
+	} else if current == dm.last {
+		dm.addAlternate("J", "")
+	} else if !dm.stringAt(current+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !dm.stringAt(current-1, 1, "S", "K", "L") {
+		dm.add("J")
+	}
+	return current + dm.skipDouble(current, 'J')
+}
+
+func (dm *doubleMetaphoneState) encodeS(current int) int {
+	// Special cases 'island', 'isle', 'carlisle', 'carlysle'
+	if dm.stringAt(current-1, 3, "ISL", "YSL") {
+		return current + 1
+	}
+	// Special case 'sugar-'
+	if current == 0 && dm.stringAt(current, 5, "SUGAR") {
+		dm.addAlternate("X", "S")
+		return current + 1
+	}
+	if dm.stringAt(current, 2, "SH") {
+		// Germanic
+		if dm.stringAt(current+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			dm.add("S")
+		} else {
+			dm.add("X")
+		}
+		return current + 2
+	}
+	// Italian & Armenian
+	if dm.stringAt(current, 3, "SIO", "SIA") || dm.stringAt(current, 4, "SIAN") {
+		if !dm.slavoGermanic {
+			dm.addAlternate("S", "X")
+		} else {
+			dm.add("S")
+		}
+		return current + 3
+	}
+	// German & anglicisations, e.g. 'smith' matches 'schmidt', 'snider' matches 'schneider';
+	// also -sz- in Slavic languages, although in Hungarian it is pronounced 's'
+	if (current == 0 && dm.stringAt(current+1, 1, "M", "N", "L", "W")) || dm.stringAt(current+1, 1, "Z") {
+		dm.addAlternate("S", "X")
+		if dm.stringAt(current+1, 1, "Z") {
+			return current + 2
+		}
+		return current + 1
+	}
+	if dm.stringAt(current, 2, "SC") {
+		// Schlesinger's rule
+		if dm.at(current+2) == 'H' {
+			// Dutch origin, e.g. 'school', 'schooner'
+			if dm.stringAt(current+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+				// 'schermerhorn', 'schenker'
+				if dm.stringAt(current+3, 2, "ER", "EN") {
+					dm.addAlternate("X", "SK")
+				} else {
+					dm.add("SK")
+				}
+				return current + 3
+			}
+			if current == 0 && !dm.isVowel(3) && dm.at(3) != 'W' {
+				dm.addAlternate("X", "S")
+			} else {
+				dm.add("X")
+			}
+			return current + 3
+		}
+		if dm.stringAt(current+2, 1, "I", "E", "Y") {
+			dm.add("S")
+			return current + 3
+		}
+		dm.add("SK")
+		return current + 3
+	}
+	// French, e.g. 'resnais', 'artois'
+	if current == dm.last && dm.stringAt(current-2, 2, "AI", "OI") {
+		dm.addAlternate("", "S")
+	} else {
+		dm.add("S")
+	}
+	if dm.stringAt(current+1, 1, "S", "Z") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphoneState) encodeT(current int) int {
+	if dm.stringAt(current, 4, "TION") || dm.stringAt(current, 3, "TIA", "TCH") {
+		dm.add("X")
+		return current + 3
+	}
+	if dm.stringAt(current, 2, "TH") || dm.stringAt(current, 3, "TTH") {
+		// Special case 'thomas', 'thames' or Germanic
+		if dm.stringAt(current+2, 2, "OM", "AM") || dm.germanic() {
+			dm.add("T")
+		} else {
+			dm.addAlternate("0", "T")
+		}
+		return current + 2
+	}
+	dm.add("T")
+	if dm.stringAt(current+1, 1, "T", "D") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphoneState) encodeW(current int) int {
+	// Can also be in the middle of the word
+	if dm.stringAt(current, 2, "WR") {
+		dm.add("R")
+		return current + 2
+	}
+	if current == 0 && (dm.isVowel(current+1) || dm.stringAt(current, 2, "WH")) {
+		if dm.isVowel(current + 1) {
+			// Wasserman should match Vasserman
+			dm.addAlternate("A", "F")
+		} else {
+			// Need Uomo to match Womo
+			dm.add("A")
+		}
+	}
+	// Arnow should match Arnoff
+	if (current == dm.last && dm.isVowel(current-1)) || dm.stringAt(current-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") || dm.stringAt(0, 3, "SCH") {
+		dm.addAlternate("", "F")
+		return current + 1
+	}
+	// Polish, e.g. 'filipowicz'
+	if dm.stringAt(current, 4, "WICZ", "WITZ") {
+		dm.addAlternate("TS", "FX")
+		return current + 4
+	}
+	return current + 1
+}
@@ -0,0 +1,135 @@
+package domain
+
+import "slices"
+
+// SurnameMatch describes how the paternal and maternal surnames of two names relate under the
+// Spanish double-surname convention
+type SurnameMatch string
+
+const (
+	// SurnamesMatch means the paternal surnames match, and so do the maternal ones when both names carry one
+	SurnamesMatch SurnameMatch = "surnames_match"
+	// SurnameMaternalDropped means the paternal surnames match and one name leaves out the maternal surname
+	SurnameMaternalDropped SurnameMatch = "maternal_dropped"
+	// SurnamesSwapped means both names carry the same two surnames in opposite order
+	SurnamesSwapped SurnameMatch = "swapped"
+	// SurnamePaternalDropped means one name only carries the maternal surname of the other
+	SurnamePaternalDropped SurnameMatch = "paternal_dropped"
+	// SurnameMaternalConflict means the paternal surnames match but the maternal surnames differ
+	SurnameMaternalConflict SurnameMatch = "maternal_conflict"
+	// SurnamesDiffer means none of the above applies
+	SurnamesDiffer SurnameMatch = "different"
+)
+
+// devotionalNames are the advocations that complete Spanish given names ("María del Carmen", "José
+// de Jesús"), so that with the particle before them they belong to the given name, not the surname
+var devotionalNames = map[string]bool{
+	"angeles": true, "carmen": true, "consuelo": true, "dios": true, "dolores": true, "fatima": true,
+	"jesus": true, "lourdes": true, "luz": true, "mar": true, "mercedes": true, "milagros": true,
+	"pilar": true, "remedios": true, "rocio": true, "rosario": true, "socorro": true,
+}
+
+// splitDoubleSurname splits the surname of a parsed name into the paternal and maternal surname,
+// following the Spanish convention: "Brayan Ferney Perez Moreno" has the given names Brayan Ferney,
+// the paternal surname Perez and the maternal surname Moreno, and "Ortega y Gasset" joins both
+// surnames with y. In a name written given name first the paternal surname is the name before the
+// surname found by Parse when it opens with a particle ("Juan de la Cruz Perez"), when the name has
+// at least four tokens, or when it is not a given name the nickname dictionary knows: "Brayan Perez
+// Moreno" has two surnames, but "Ana Maria Lopez" has a middle name, and so has "Maria del Carmen
+// Lopez" (see devotionalNames). A name with a single surname only has a paternal surname.
+func (m *NameMatcher) splitDoubleSurname(parsed ParsedName, order NameOrder) ParsedName {
+	surname := parsed.Surname
+	switch i := slices.Index(surname, surnameConjunction); {
+	case len(surname) == 0:
+		return parsed
+	case i > 0 && i < len(surname)-1:
+		parsed.PaternalSurname, parsed.MaternalSurname = surname[:i], surname[i+1:]
+	case order == OrderFamilyFirst:
+		// "Perez Moreno, Brayan": every token before the comma is a surname
+		if start := surnameStart(surname); start > 0 {
+			parsed.PaternalSurname, parsed.MaternalSurname = surname[:start], surname[start:]
+		} else {
+			parsed.PaternalSurname = surname
+		}
+	case parsed.Given == "" || len(parsed.Middle) == 0:
+		parsed.PaternalSurname = surname
+	default:
+		names := append([]string{parsed.Given}, parsed.Middle...)
+		start := max(surnameStart(names), 1)
+		last := names[len(names)-1]
+		particleLed := start < len(names)-1
+		if devotionalNames[last] || !particleLed && len(names)+len(surname) < 4 && m.nicknames.Contains(last) {
+			parsed.PaternalSurname = surname
+			return parsed
+		}
+		parsed.PaternalSurname, parsed.MaternalSurname = names[start:], surname
+		parsed.Surname = append(slices.Clone(names[start:]), surname...)
+		parsed.Middle = names[1:start]
+		if len(parsed.Middle) == 0 {
+			parsed.Middle = nil
+		}
+	}
+	return parsed
+}
+
+// compareDoubleSurnames compares the surnames of two names split by splitDoubleSurname. Matching
+// paternal and maternal surnames are an exact match. Leaving out the maternal surname keeps the
+// configured share of the paternal surname score, and so do swapped surnames and a name that only
+// carries the maternal surname of the other, so that dropping the maternal surname costs least
+// and dropping the paternal one most.
+func (m *NameMatcher) compareDoubleSurnames(parsed1, parsed2 ParsedName, state *comparisonState) TokenComparison {
+	comparison := m.compareToken(surnameOrGiven(parsed1), surnameOrGiven(parsed2), state)
+	if len(parsed1.PaternalSurname) == 0 || len(parsed2.PaternalSurname) == 0 {
+		return comparison
+	}
+	compare := func(surname1, surname2 []string) TokenComparison {
+		return m.compareToken(surnameCore(surname1), surnameCore(surname2), state)
+	}
+	judge := func(match SurnameMatch, score float64) TokenComparison {
+		comparison.SurnameMatch = match
+		comparison.Score = score
+		comparison.ExactMatch = match == SurnamesMatch
+		return comparison
+	}
+
+	config := m.config
+	paternal1, maternal1 := parsed1.PaternalSurname, parsed1.MaternalSurname
+	paternal2, maternal2 := parsed2.PaternalSurname, parsed2.MaternalSurname
+	paternal := compare(paternal1, paternal2)
+	switch {
+	case len(maternal1) == 0 && len(maternal2) == 0:
+		if paternal.ExactMatch {
+			return judge(SurnamesMatch, paternal.Score)
+		}
+		return judge(SurnamesDiffer, paternal.Score)
+
+	case len(maternal1) > 0 && len(maternal2) > 0:
+		maternal := compare(maternal1, maternal2)
+		if paternal.ExactMatch && maternal.ExactMatch {
+			return judge(SurnamesMatch, (paternal.Score+maternal.Score)/2)
+		}
+		if paternal.ExactMatch {
+			return judge(SurnameMaternalConflict, (paternal.Score+maternal.Score)/2)
+		}
+		cross1, cross2 := compare(paternal1, maternal2), compare(maternal1, paternal2)
+		if cross1.ExactMatch && cross2.ExactMatch {
+			return judge(SurnamesSwapped, (cross1.Score+cross2.Score)/2*config.SwappedSurnamesWeight)
+		}
+		// A different paternal surname outweighs a shared maternal one
+		return judge(SurnamesDiffer, min(paternal.Score, maternal.Score))
+
+	default:
+		// One name carries a single surname: the paternal surname, or the maternal one alone
+		maternal, single := maternal1, paternal2
+		if len(maternal1) == 0 {
+			maternal, single = maternal2, paternal1
+		}
+		if paternal.ExactMatch {
+			return judge(SurnameMaternalDropped, paternal.Score*config.MaternalSurnameDropWeight)
+		}
+		if onlyMaternal := compare(maternal, single); onlyMaternal.ExactMatch {
+			return judge(SurnamePaternalDropped, onlyMaternal.Score*config.PaternalSurnameDropWeight)
+		}
+		return judge(SurnamesDiffer, paternal.Score)
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitDoubleSurname(t *testing.T) {
+	tests := []struct {
+		name               string
+		given              string
+		middle             []string
+		paternal, maternal []string
+	}{
+		{"Brayan Ferney Perez Moreno", "brayan", []string{"ferney"}, []string{"perez"}, []string{"moreno"}},
+		{"Brayan Perez", "brayan", nil, []string{"perez"}, nil},
+		{"José Ortega y Gasset", "jose", nil, []string{"ortega"}, []string{"gasset"}},
+		{"Juan de la Cruz Perez", "juan", nil, []string{"de", "la", "cruz"}, []string{"perez"}},
+		{"Perez Moreno, Brayan Ferney", "brayan", []string{"ferney"}, []string{"perez"}, []string{"moreno"}},
+		{"Ana Maria Lopez", "ana", []string{"maria"}, []string{"lopez"}, nil},
+		{"Maria del Carmen Lopez", "maria", []string{"del", "carmen"}, []string{"lopez"}, nil},
+		{"Maria del Carmen Lopez Garcia", "maria", []string{"del", "carmen"}, []string{"lopez"}, []string{"garcia"}},
+	}
+	matcher := DefaultNameMatcher()
+	for _, tt := range tests {
+		parsed, order := matcher.parseInOrder(tt.name, OrderAuto)
+		got := matcher.splitDoubleSurname(parsed, order)
+		if got.Given != tt.given || !reflect.DeepEqual(got.Middle, tt.middle) ||
+			!reflect.DeepEqual(got.PaternalSurname, tt.paternal) || !reflect.DeepEqual(got.MaternalSurname, tt.maternal) {
+			t.Errorf("splitDoubleSurname('%s') = %+v", tt.name, got)
+		}
+	}
+}
+
+func TestCompareDoubleSurnames(t *testing.T) {
+	options := CompareOptionsForCulture("es-CO")
+	compare := func(name1, name2 string) NameMatchResult {
+		return DefaultNameMatcher().CompareWithOptions(name1, name2, options)
+	}
+
+	full := compare("Brayan Ferney Perez Moreno", "Brayan Perez Moreno")
+	maternalDropped := compare("Brayan Perez Moreno", "Brayan Perez")
+	swapped := compare("Brayan Perez Moreno", "Brayan Moreno Perez")
+	paternalDropped := compare("Brayan Perez Moreno", "Brayan Moreno")
+	different := compare("Brayan Perez Moreno", "Brayan Garcia Moreno")
+
+	if full.Score != 1.0 || full.LastName.SurnameMatch != SurnamesMatch {
+		t.Errorf("Expected matching surnames to score 1.00, got %.2f (%s)", full.Score, full.LastName.SurnameMatch)
+	}
+	if maternalDropped.LastName.SurnameMatch != SurnameMaternalDropped || maternalDropped.Score < 0.95 {
+		t.Errorf("Expected a dropped maternal surname to be a near-perfect match, got %.2f (%s)",
+			maternalDropped.Score, maternalDropped.LastName.SurnameMatch)
+	}
+	if swapped.LastName.SurnameMatch != SurnamesSwapped || paternalDropped.LastName.SurnameMatch != SurnamePaternalDropped {
+		t.Errorf("Expected swapped and paternal-dropped surnames, got %s and %s",
+			swapped.LastName.SurnameMatch, paternalDropped.LastName.SurnameMatch)
+	}
+	if !(maternalDropped.Score > swapped.Score && swapped.Score > paternalDropped.Score && paternalDropped.Score > different.Score) {
+		t.Errorf("Expected maternal dropped (%.2f) > swapped (%.2f) > paternal dropped (%.2f) > different (%.2f)",
+			maternalDropped.Score, swapped.Score, paternalDropped.Score, different.Score)
+	}
+	if different.Score >= 0.8 {
+		t.Errorf("Expected different paternal surnames not to match, got %.2f", different.Score)
+	}
+
+	if score := compare("José Ortega y Gasset", "José Ortega").Score; score < 0.95 {
+		t.Errorf("Expected 'Ortega y Gasset' to keep Ortega as the paternal surname, got %.2f", score)
+	}
+	if score := compare("Brayan Ferney Perez Moreno", "Ferney Perez").Score; score < 0.95 {
+		t.Errorf("Expected the second given name to stand for the name, got %.2f", score)
+	}
+
+	// A middle name is not a paternal surname
+	for _, pair := range [][2]string{{"Ana Maria Lopez", "Ana Lopez"}, {"Maria del Carmen Lopez", "Maria Lopez"}} {
+		if result := compare(pair[0], pair[1]); result.Score != 1.0 {
+			t.Errorf("'%s' vs '%s': expected 1.00, got %.2f (%s)", pair[0], pair[1], result.Score, result.LastName.SurnameMatch)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+)
+
+// EmailProvider describes how a mailbox provider delivers mail, so addresses it treats as the same
+// mailbox canonicalize to the same string
+type EmailProvider struct {
+	// Name identifies the provider
+	Name string
+	// Domains are the domains the provider serves the same mailboxes under; the first is canonical
+	Domains []string
+	// IgnoreDots is set when dots in the local part are not significant ("j.o.h.n" is "john")
+	IgnoreDots bool
+	// PlusAddressing is set when anything after a "+" in the local part is a tag for the same
+	// mailbox ("john+promo" is "john")
+	PlusAddressing bool
+}
+
+// emailProviders are the providers whose addressing rules are known. Only domains that deliver to
+// the same mailboxes are listed together: Microsoft's outlook.com, hotmail.com, live.com and msn.com
+// are separate namespaces where "john@" may be four different people, and so are Fastmail's domains.
+var emailProviders = []EmailProvider{
+	{Name: "gmail", Domains: []string{"gmail.com", "googlemail.com"}, IgnoreDots: true, PlusAddressing: true},
+	{Name: "outlook", Domains: []string{"outlook.com"}, PlusAddressing: true},
+	{Name: "hotmail", Domains: []string{"hotmail.com"}, PlusAddressing: true},
+	{Name: "live", Domains: []string{"live.com"}, PlusAddressing: true},
+	{Name: "msn", Domains: []string{"msn.com"}, PlusAddressing: true},
+	{Name: "icloud", Domains: []string{"icloud.com", "me.com", "mac.com"}, PlusAddressing: true},
+	{Name: "proton", Domains: []string{"proton.me", "protonmail.com", "protonmail.ch", "pm.me"}, PlusAddressing: true},
+	{Name: "fastmail", Domains: []string{"fastmail.com"}, PlusAddressing: true},
+	{Name: "fastmail.fm", Domains: []string{"fastmail.fm"}, PlusAddressing: true},
+	{Name: "yandex", Domains: []string{"yandex.ru", "yandex.com", "ya.ru"}, PlusAddressing: true},
+}
+
+// emailProvidersByDomain indexes emailProviders by every domain they serve
+var emailProvidersByDomain = func() map[string]*EmailProvider {
+	index := map[string]*EmailProvider{}
+	for i := range emailProviders {
+		for _, domain := range emailProviders[i].Domains {
+			index[domain] = &emailProviders[i]
+		}
+	}
+	return index
+}()
+
+// EmailProviderFor returns the provider serving an ASCII domain, or nil when its rules are unknown
+func EmailProviderFor(domain string) *EmailProvider {
+	return emailProvidersByDomain[domain]
+}
+
+// EmailAddress is an email address split into its local part and its domain
+type EmailAddress struct {
+	Local  string `json:"local"`
+	Domain string `json:"domain"`
+}
+
+// String returns the address as local@domain
+func (a EmailAddress) String() string {
+	if a.Domain == "" {
+		return a.Local
+	}
+	return a.Local + "@" + a.Domain
+}
+
+// SplitEmail splits an address at its last "@" after trimming spaces, a "mailto:" scheme and
+// angle brackets ("<John@Example.com>"). An address without "@" is all local part.
+func SplitEmail(email string) EmailAddress {
+	email = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(email), "<"), ">"))
+	if len(email) >= len("mailto:") && strings.EqualFold(email[:len("mailto:")], "mailto:") {
+		email = email[len("mailto:"):]
+	}
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return EmailAddress{Local: email}
+	}
+	return EmailAddress{Local: email[:at], Domain: email[at+1:]}
+}
+
+// CanonicalizeEmail returns the canonical form of an email address, equal for every spelling of
+// the same mailbox ("J.O.H.N+promo@GMail.com" and "john@googlemail.com" are "john@gmail.com").
+// The address is lowercased and normalized (NFC) and its domain converted to ASCII (see
+// DomainToASCII). For known providers (see EmailProviderFor) the domain is replaced with the
+// provider's canonical domain, plus-address tags are removed and, where the provider ignores
+// them, so are the dots of the local part.
+func CanonicalizeEmail(email string) string {
+	address := SplitEmail(email)
+	address.Local = norm.NFC.String(strings.ToLower(address.Local))
+	if address.Domain == "" {
+		return address.String()
+	}
+	address.Domain = DomainToASCII(address.Domain)
+
+	if provider := EmailProviderFor(address.Domain); provider != nil {
+		address.Domain = provider.Domains[0]
+		if plus := strings.IndexByte(address.Local, '+'); provider.PlusAddressing && plus > 0 {
+			address.Local = address.Local[:plus]
+		}
+		if provider.IgnoreDots {
+			address.Local = strings.ReplaceAll(address.Local, ".", "")
+		}
+	}
+	return address.String()
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "testing"
+
+func TestCanonicalizeEmail(t *testing.T) {
+	tests := []struct{ email, want string }{
+		{"j.o.h.n+promo@gmail.com", "john@gmail.com"},
+		{"John@GoogleMail.com", "john@gmail.com"},
+		{"john.smith+news@hotmail.com", "john.smith@hotmail.com"},
+		{"John.Smith@Live.com", "john.smith@live.com"},
+		{"jane@me.com", "jane@icloud.com"},
+		{"john.smith+news@example.com", "john.smith+news@example.com"},
+		{" <mailto:John@Example.COM.> ", "john@example.com"},
+		{"josé@Bücher.de", "josé@xn--bcher-kva.de"},
+		{"info@XN--BCHER-KVA.de", "info@xn--bcher-kva.de"},
+		{"john", "john"},
+	}
+	for _, tt := range tests {
+		if got := CanonicalizeEmail(tt.email); got != tt.want {
+			t.Errorf("CanonicalizeEmail('%s') = '%s', want '%s'", tt.email, got, tt.want)
+		}
+	}
+}
+
+func TestPunycode(t *testing.T) {
+	tests := []struct{ unicode, ascii string }{
+		{"bücher.de", "xn--bcher-kva.de"},
+		{"münchen.de", "xn--mnchen-3ya.de"},
+		{"例え.テスト", "xn--r8jz45g.xn--zckzah"},
+		{"example.com", "example.com"},
+	}
+	for _, tt := range tests {
+		if got := DomainToASCII(tt.unicode); got != tt.ascii {
+			t.Errorf("DomainToASCII('%s') = '%s', want '%s'", tt.unicode, got, tt.ascii)
+		}
+		if got := DomainToUnicode(tt.ascii); got != tt.unicode {
+			t.Errorf("DomainToUnicode('%s') = '%s', want '%s'", tt.ascii, got, tt.unicode)
+		}
+	}
+	if got := DomainToUnicode("xn--ab$.com"); got != "xn--ab$.com" {
+		t.Errorf("Expected invalid punycode to be kept, got '%s'", got)
+	}
+	for domain, want := range map[string]string{"Bücher.DE.": "xn--bcher-kva.de", "例え。テスト": "xn--r8jz45g.xn--zckzah", "EXA_MPLE.com": "exa_mple.com"} {
+		if got := DomainToASCII(domain); got != want {
+			t.Errorf("DomainToASCII('%s') = '%s', want '%s'", domain, got, want)
+		}
+	}
+}
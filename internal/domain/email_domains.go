@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// popularEmailDomains are the domains of the most used mailbox providers, most used first. A domain
+// a single slip away from one of them is far more likely a typo than a real domain.
+var popularEmailDomains = []string{
+	"gmail.com", "yahoo.com", "hotmail.com", "outlook.com", "icloud.com", "aol.com", "live.com",
+	"msn.com", "googlemail.com", "me.com", "mac.com", "mail.com", "gmx.com", "gmx.de", "gmx.net",
+	"web.de", "t-online.de", "protonmail.com", "proton.me", "zoho.com", "yandex.ru", "mail.ru",
+	"qq.com", "163.com", "126.com", "naver.com", "yahoo.co.uk", "yahoo.co.jp", "yahoo.fr",
+	"yahoo.es", "yahoo.com.br", "yahoo.com.mx", "hotmail.co.uk", "hotmail.fr", "hotmail.es",
+	"hotmail.it", "outlook.es", "orange.fr", "free.fr", "wanadoo.fr", "laposte.net", "sfr.fr",
+	"libero.it", "virgilio.it", "btinternet.com", "sky.com", "ymail.com", "rocketmail.com",
+	"comcast.net", "verizon.net", "att.net", "sbcglobal.net", "bellsouth.net", "cox.net",
+	"charter.net", "shaw.ca", "rogers.com", "uol.com.br", "bol.com.br", "terra.com.br",
+}
+
+// popularEmailDomainSet indexes popularEmailDomains
+var popularEmailDomainSet = func() map[string]bool {
+	set := make(map[string]bool, len(popularEmailDomains))
+	for _, domain := range popularEmailDomains {
+		set[domain] = true
+	}
+	return set
+}()
+
+// SuggestEmailDomain returns the popular provider domain an ASCII domain is likely a typo of
+// ("gmial.com" is "gmail.com", "hotmail.con" is "hotmail.com"), or false when the domain is a
+// popular one itself or not close to any. Domains longer than ten characters may be two edits away;
+// swapping adjacent characters counts as one edit. Shorter domains may only be a slip away (see
+// isTypingSlip), as a letter replaced in a short domain often spells another real domain ("box.net"
+// and "cox.net", "email.com" and "gmail.com").
+func SuggestEmailDomain(domain string) (string, bool) {
+	domain = strings.ToLower(domain)
+	if domain == "" || popularEmailDomainSet[domain] {
+		return "", false
+	}
+	runes := []rune(domain)
+	allowed := 1
+	if len(runes) > 10 {
+		allowed = 2
+	}
+
+	suggestion, best := "", allowed+1
+	for _, popular := range popularEmailDomains {
+		if abs(utf8.RuneCountInString(popular)-len(runes)) > allowed {
+			continue
+		}
+		candidate := []rune(popular)
+		if distance := damerauLevenshteinDistance(runes, candidate); distance < best && (allowed > 1 || isTypingSlip(runes, candidate)) {
+			suggestion, best = popular, distance
+		}
+	}
+	return suggestion, suggestion != ""
+}
+
+// isTypingSlip reports whether typed is intended with two adjacent characters swapped ("gmial"),
+// one character left out ("yaho") or one character repeated ("gmaill")
+func isTypingSlip(typed, intended []rune) bool {
+	switch len(typed) - len(intended) {
+	case 0:
+		i := 0
+		for i < len(typed) && typed[i] == intended[i] {
+			i++
+		}
+		return i+1 < len(typed) && typed[i] == intended[i+1] && typed[i+1] == intended[i] &&
+			slices.Equal(typed[i+2:], intended[i+2:])
+	case -1:
+		for i := range intended {
+			if slices.Equal(typed, slices.Concat(intended[:i], intended[i+1:])) {
+				return true
+			}
+		}
+	case 1:
+		for i := 1; i < len(typed); i++ {
+			if typed[i] == typed[i-1] && slices.Equal(slices.Concat(typed[:i], typed[i+1:]), intended) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// abs returns the absolute value of an integer
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package domain
+
+import "strings"
+
+// roleLocalParts are local parts of mailboxes that belong to a function of an organization rather
+// than to a person, without separators ("no-reply" is "noreply")
+var roleLocalParts = map[string]bool{
+	"abuse": true, "accounting": true, "accounts": true, "admin": true, "administrator": true,
+	"billing": true, "careers": true, "contact": true, "contactus": true, "customerservice": true,
+	"donotreply": true, "enquiries": true, "feedback": true, "finance": true, "hello": true,
+	"help": true, "helpdesk": true, "hostmaster": true, "hr": true, "info": true, "jobs": true,
+	"legal": true, "mail": true, "mailerdaemon": true, "marketing": true, "media": true,
+	"news": true, "newsletter": true, "noc": true, "noreply": true, "notifications": true,
+	"office": true, "orders": true, "postmaster": true, "press": true, "privacy": true,
+	"reception": true, "root": true, "sales": true, "security": true, "service": true,
+	"support": true, "team": true, "webmaster": true,
+}
+
+// EmailRisk flags the properties of an email address that make it weak evidence of identity
+type EmailRisk struct {
+	// Disposable is set for addresses at temporary mailbox services (see DisposableDomains)
+	Disposable bool `json:"disposable"`
+	// RoleAccount is set for mailboxes of a function rather than a person ("info@", "noreply@")
+	RoleAccount bool `json:"role_account"`
+	// InvalidSyntax is set for addresses that are not valid per RFC 5322 and RFC 6531, with the
+	// reason in InvalidReason (see ValidateEmailSyntax)
+	InvalidSyntax bool   `json:"invalid_syntax"`
+	InvalidReason string `json:"invalid_reason,omitempty"`
+}
+
+// Flagged reports whether any risk was found
+func (r EmailRisk) Flagged() bool {
+	return r.Disposable || r.RoleAccount || r.InvalidSyntax
+}
+
+// WithDisposableDomains sets the list of disposable email domains the matcher flags. Without it the
+// matcher uses DefaultDisposableDomains; nil disables disposable domain detection.
+func WithDisposableDomains(domains *DisposableDomains) MatcherOption {
+	return func(m *NameMatcher) {
+		m.disposableDomains = domains
+	}
+}
+
+// DisposableDomains returns the list of disposable email domains of the matcher, or nil when
+// disposable domain detection is disabled
+func (m *NameMatcher) DisposableDomains() *DisposableDomains {
+	return m.disposableDomains
+}
+
+// ClassifyEmail flags the risks of an email address with the default matcher (see NameMatcher.ClassifyEmail)
+func ClassifyEmail(email string) EmailRisk {
+	return defaultNameMatcher.ClassifyEmail(email)
+}
+
+// ClassifyEmail flags an email address that is disposable, a role account or syntactically invalid.
+// Spaces, angle brackets and a "mailto:" scheme around the address are tolerated (see SplitEmail).
+// An empty address has no risks; it is missing rather than wrong.
+func (m *NameMatcher) ClassifyEmail(email string) EmailRisk {
+	var risk EmailRisk
+	address := SplitEmail(email)
+	if address.String() == "" {
+		return risk
+	}
+	if err := ValidateEmailSyntax(address.String()); err != nil {
+		risk.InvalidSyntax, risk.InvalidReason = true, err.Error()
+	}
+
+	local := strings.ToLower(address.Local)
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	risk.RoleAccount = roleLocalParts[strings.NewReplacer(".", "", "-", "", "_", "").Replace(local)]
+	if address.Domain != "" {
+		risk.Disposable = m.disposableDomains.Contains(DomainToASCII(address.Domain))
+	}
+	return risk
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestClassifyEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  EmailRisk
+	}{
+		{"john.smith@example.com", EmailRisk{}},
+		{"", EmailRisk{}},
+		{"john@mailinator.com", EmailRisk{Disposable: true}},
+		{"john@inbox.Mailinator.com", EmailRisk{Disposable: true}},
+		{"info@example.com", EmailRisk{RoleAccount: true}},
+		{"No-Reply+alerts@example.com", EmailRisk{RoleAccount: true}},
+		{"admin@yopmail.com", EmailRisk{Disposable: true, RoleAccount: true}},
+	}
+	for _, test := range tests {
+		if got := ClassifyEmail(test.email); got != test.want {
+			t.Errorf("ClassifyEmail(%q) = %+v, want %+v", test.email, got, test.want)
+		}
+	}
+
+	if risk := ClassifyEmail("john..smith@example.com"); !risk.InvalidSyntax || risk.InvalidReason == "" || !risk.Flagged() {
+		t.Errorf("Expected a double dot to be invalid, got %+v", risk)
+	}
+	if risk := ClassifyEmail(" <mailto:John@Example.com> "); risk.Flagged() {
+		t.Errorf("Expected a bracketed mailto address to be valid, got %+v", risk)
+	}
+}
+
+func TestDisposableDomainsExtend(t *testing.T) {
+	domains := DefaultDisposableDomains().Extend([]string{"Wegwerf-Ämail.de"})
+	if !domains.Contains("xn--wegwerf-mail-ncb.de") || !domains.Contains("mailinator.com") {
+		t.Errorf("Expected the extended list to hold bundled and custom domains")
+	}
+	if DefaultDisposableDomains().Contains("xn--wegwerf-mail-tfb.de") {
+		t.Errorf("Expected Extend to leave the bundled list unchanged")
+	}
+	if domains.Contains("example.com") {
+		t.Errorf("Expected 'example.com' not to be disposable")
+	}
+
+	matcher := NewNameMatcher(DefaultScoringConfig(), WithDisposableDomains(nil))
+	if matcher.ClassifyEmail("john@mailinator.com").Disposable {
+		t.Errorf("Expected a nil list to disable disposable domain detection")
+	}
+}
+
+func TestCompareEmailsReturnsRisks(t *testing.T) {
+	result := CompareEmails("john@mailinator.com", "john@mailinator.com")
+	if result.Score != 1.0 || !result.Risk1.Disposable || !result.Risk2.Disposable || result.Trusted() {
+		t.Errorf("Expected identical disposable addresses to score 1.0 but not be trusted, got %+v", result)
+	}
+	if result := CompareEmails("john@example.com", "jon@example.com"); !result.Trusted() {
+		t.Errorf("Expected personal addresses to be trusted, got %+v", result)
+	}
+}
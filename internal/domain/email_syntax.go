@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length limits of email addresses, in octets (RFC 5321, section 4.5.3.1)
+const (
+	maxEmailLength       = 254
+	maxEmailLocalLength  = 64
+	maxEmailDomainLength = 253
+	maxDomainLabelLength = 63
+)
+
+// atextSpecials are the symbols allowed in the atoms of a local part besides letters and digits
+const atextSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+// ErrInvalidEmail is wrapped by every error ValidateEmailSyntax returns
+var ErrInvalidEmail = errors.New("invalid email address")
+
+// ValidateEmailSyntax checks that an email address is a valid addr-spec (RFC 5322, section 3.4.1)
+// with the UTF-8 characters of internationalized addresses (RFC 6531): a dot-atom or quoted-string
+// local part, and a host name or a bracketed IP address literal as domain. Host names need at
+// least two labels, as mail is not delivered to top-level domains. Comments and folding white
+// space, obsolete in addresses and never legitimate in customer records, are rejected.
+func ValidateEmailSyntax(email string) error {
+	if !utf8.ValidString(email) {
+		return fmt.Errorf("%w: not valid UTF-8", ErrInvalidEmail)
+	}
+	if len(email) > maxEmailLength {
+		return fmt.Errorf("%w: longer than %d octets", ErrInvalidEmail, maxEmailLength)
+	}
+
+	local, domain, err := splitAddrSpec(email)
+	if err != nil {
+		return err
+	}
+	if len(local) > maxEmailLocalLength {
+		return fmt.Errorf("%w: local part longer than %d octets", ErrInvalidEmail, maxEmailLocalLength)
+	}
+	if strings.HasPrefix(local, `"`) {
+		err = validateQuotedString(local)
+	} else {
+		err = validateDotAtom(local)
+	}
+	if err != nil {
+		return err
+	}
+	return validateEmailDomain(domain)
+}
+
+// splitAddrSpec splits an address into its local part and domain at the "@" after the local part,
+// which may itself contain "@" when quoted
+func splitAddrSpec(email string) (local, domain string, err error) {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return "", "", fmt.Errorf("%w: missing @", ErrInvalidEmail)
+	}
+	local, domain = email[:at], email[at+1:]
+	if local == "" {
+		return "", "", fmt.Errorf("%w: empty local part", ErrInvalidEmail)
+	}
+	if domain == "" {
+		return "", "", fmt.Errorf("%w: empty domain", ErrInvalidEmail)
+	}
+	return local, domain, nil
+}
+
+// validateDotAtom checks a dot-atom local part: atoms of atext separated by single dots
+func validateDotAtom(local string) error {
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return fmt.Errorf("%w: local part has an empty atom (leading, trailing or double dot)", ErrInvalidEmail)
+		}
+		for _, r := range atom {
+			if !isAtext(r) {
+				return fmt.Errorf("%w: character %q not allowed in an unquoted local part", ErrInvalidEmail, r)
+			}
+		}
+	}
+	return nil
+}
+
+// isAtext reports whether a rune may appear in an atom: an ASCII letter or digit, one of
+// atextSpecials, or any non-ASCII character (RFC 6531, UTF8-non-ascii)
+func isAtext(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case r < utf8.RuneSelf:
+		return strings.ContainsRune(atextSpecials, r)
+	default:
+		return r >= 0xA0
+	}
+}
+
+// validateQuotedString checks a quoted-string local part: printable characters and spaces between
+// double quotes, with quotes and backslashes escaped by a backslash
+func validateQuotedString(local string) error {
+	if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+		return fmt.Errorf("%w: unterminated quoted local part", ErrInvalidEmail)
+	}
+	escaped := false
+	for _, r := range local[1 : len(local)-1] {
+		switch {
+		case escaped:
+			if r < ' ' && r != '\t' || r == 0x7F {
+				return fmt.Errorf("%w: invalid escaped character %q", ErrInvalidEmail, r)
+			}
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			return fmt.Errorf("%w: unescaped quote in quoted local part", ErrInvalidEmail)
+		case r < ' ' && r != '\t' || r == 0x7F:
+			return fmt.Errorf("%w: control character in quoted local part", ErrInvalidEmail)
+		}
+	}
+	if escaped {
+		return fmt.Errorf("%w: unterminated quoted local part", ErrInvalidEmail)
+	}
+	return nil
+}
+
+// validateEmailDomain checks a host name, Unicode labels allowed, or an address literal
+// ("[192.0.2.1]", "[IPv6:2001:db8::1]")
+func validateEmailDomain(domain string) error {
+	if strings.HasPrefix(domain, "[") {
+		return validateAddressLiteral(domain)
+	}
+
+	ascii := DomainToASCII(domain)
+	if len(ascii) > maxEmailDomainLength {
+		return fmt.Errorf("%w: domain longer than %d octets", ErrInvalidEmail, maxEmailDomainLength)
+	}
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 || strings.HasSuffix(domain, ".") {
+		return fmt.Errorf("%w: domain %q is not a fully qualified host name", ErrInvalidEmail, domain)
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxDomainLabelLength {
+			return fmt.Errorf("%w: domain label must have 1 to %d characters", ErrInvalidEmail, maxDomainLabelLength)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("%w: domain label %q starts or ends with a hyphen", ErrInvalidEmail, label)
+		}
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-') {
+				return fmt.Errorf("%w: character %q not allowed in a domain", ErrInvalidEmail, c)
+			}
+		}
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return fmt.Errorf("%w: top-level domain %q is numeric", ErrInvalidEmail, labels[len(labels)-1])
+	}
+	return nil
+}
+
+// validateAddressLiteral checks a bracketed IPv4 or IPv6 address literal (RFC 5321, section 4.1.3)
+func validateAddressLiteral(domain string) error {
+	if !strings.HasSuffix(domain, "]") {
+		return fmt.Errorf("%w: unterminated address literal", ErrInvalidEmail)
+	}
+	literal := domain[1 : len(domain)-1]
+	if len(literal) > 5 && strings.EqualFold(literal[:5], "IPv6:") {
+		if addr, err := netip.ParseAddr(literal[5:]); err == nil && addr.Is6() && addr.Zone() == "" {
+			return nil
+		}
+		return fmt.Errorf("%w: invalid IPv6 address literal", ErrInvalidEmail)
+	}
+	if addr, err := netip.ParseAddr(literal); err == nil && addr.Is4() {
+		return nil
+	}
+	return fmt.Errorf("%w: invalid address literal", ErrInvalidEmail)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateEmailSyntaxAcceptsValidAddresses(t *testing.T) {
+	for _, email := range []string{
+		"john@example.com",
+		"john.smith+news@mail.example.co.uk",
+		"o'brien@example.ie",
+		"!#$%&'*+-/=?^_`{|}~@example.com",
+		`"john smith"@example.com`,
+		`"john\"@home"@example.com`,
+		"josé@bücher.de",
+		"用户@例子.广告",
+		"john@xn--bcher-kva.de",
+		"john@[192.0.2.1]",
+		"john@[IPv6:2001:db8::1]",
+	} {
+		if err := ValidateEmailSyntax(email); err != nil {
+			t.Errorf("Expected '%s' to be valid, got %v", email, err)
+		}
+	}
+}
+
+func TestValidateEmailSyntaxRejectsInvalidAddresses(t *testing.T) {
+	for _, email := range []string{
+		"",
+		"john",
+		"john@",
+		"@example.com",
+		"john..smith@example.com",
+		".john@example.com",
+		"john.@example.com",
+		"john smith@example.com",
+		"john@smith@example.com",
+		`"john@example.com`,
+		`"jo"hn"@example.com`,
+		"john@localhost",
+		"john@example.com.",
+		"john@-example.com",
+		"john@exa_mple.com",
+		"john@example.123",
+		"john@[300.0.0.1]",
+		"john@[IPv6:192.0.2.1]",
+		"john(comment)@example.com",
+		strings.Repeat("a", 65) + "@example.com",
+		"john@" + strings.Repeat("a", 64) + ".com",
+	} {
+		if err := ValidateEmailSyntax(email); !errors.Is(err, ErrInvalidEmail) {
+			t.Errorf("Expected '%s' to be invalid, got %v", email, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package domain
+
+// JaroWinkler is the Jaro-Winkler similarity, the usual choice for short personal names.
+// PrefixScale (typically 0.1, at most 0.25) rewards a common prefix of up to four runes, and is
+// only applied when the plain Jaro similarity reaches BoostThreshold (typically 0.7).
+type JaroWinkler struct {
+	PrefixScale    float64
+	BoostThreshold float64
+}
+
+// Compare returns the Jaro-Winkler similarity of a and b
+func (jw JaroWinkler) Compare(a, b string) float64 {
+	r1, r2 := []rune(a), []rune(b)
+	jaro := jaroSimilarity(r1, r2)
+	if jaro < jw.BoostThreshold {
+		return jaro
+	}
+
+	prefix := 0
+	for prefix < len(r1) && prefix < len(r2) && prefix < 4 && r1[prefix] == r2[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*jw.PrefixScale*(1.0-jaro)
+}
+
+// jaroSimilarity computes the Jaro similarity of two rune slices
+func jaroSimilarity(r1, r2 []rune) float64 {
+	if len(r1) == 0 && len(r2) == 0 {
+		return 1.0
+	}
+	if len(r1) == 0 || len(r2) == 0 {
+		return 0.0
+	}
+
+	window := maxIntegers(len(r1), len(r2))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matched1 := make([]bool, len(r1))
+	matched2 := make([]bool, len(r2))
+	matches := 0
+	for i := range r1 {
+		start := maxIntegers(0, i-window)
+		end := min(len(r2), i+window+1)
+		for j := start; j < end; j++ {
+			if matched2[j] || r1[i] != r2[j] {
+				continue
+			}
+			matched1[i], matched2[j] = true, true
+			matches++
+			break
+		}
+	}
+	if matches == 0 {
+		return 0.0
+	}
+
+	// Count matched runes that appear in a different order
+	transpositions := 0
+	j := 0
+	for i := range r1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if r1[i] != r2[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(r1)) + m/float64(len(r2)) + (m-float64(transpositions)/2)/m) / 3.0
+}
@@ -0,0 +1,31 @@
+package domain
+
+// LCSRatio is the length of the longest common subsequence of two strings relative to the longer one
+type LCSRatio struct{}
+
+// Compare returns the longest common subsequence ratio of a and b
+func (LCSRatio) Compare(a, b string) float64 {
+	r1, r2 := []rune(a), []rune(b)
+	maxLen := maxIntegers(len(r1), len(r2))
+	if maxLen == 0 {
+		return 1.0
+	}
+	return float64(longestCommonSubsequence(r1, r2)) / float64(maxLen)
+}
+
+// longestCommonSubsequence returns the length of the longest common subsequence of r1 and r2
+func longestCommonSubsequence(r1, r2 []rune) int {
+	previous := make([]int, len(r2)+1)
+	current := make([]int, len(r2)+1)
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
+			if r1[i-1] == r2[j-1] {
+				current[j] = previous[j-1] + 1
+			} else {
+				current[j] = max(previous[j], current[j-1])
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(r2)]
+}
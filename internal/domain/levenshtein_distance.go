@@ -4,6 +4,14 @@ import (
 	"github.com/agnivade/levenshtein"
 )
 
+// Levenshtein is the Similarity implementation backed by LevenshteinSimilarity
+type Levenshtein struct{}
+
+// Compare returns the Levenshtein similarity of a and b
+func (Levenshtein) Compare(a, b string) float64 {
+	return LevenshteinSimilarity(a, b)
+}
+
 // LevenshteinSimilarity calculates the Levenshtein similarity score between two names
 func LevenshteinSimilarity(name1, name2 string) float64 {
 	name1 = NormalizeName(name1)
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NameEmailPattern names the way an email local part is derived from a name
+type NameEmailPattern string
+
+const (
+	// PatternFullName is every token of the name in order ("brayanferneyperezmoreno")
+	PatternFullName NameEmailPattern = "full_name"
+	// PatternFirstLast is the given name followed by a surname ("brayan.perez")
+	PatternFirstLast NameEmailPattern = "first_last"
+	// PatternLastFirst is a surname followed by the given name ("perez.brayan")
+	PatternLastFirst NameEmailPattern = "last_first"
+	// PatternInitialLast is the initials of the given and middle names followed by a surname ("bperez", "bfperez")
+	PatternInitialLast NameEmailPattern = "initial_last"
+	// PatternLastInitial is a surname followed by the initial of the given name ("perezb")
+	PatternLastInitial NameEmailPattern = "last_initial"
+	// PatternFirstLastInitial is the given name followed by the initial of a surname ("brayanp")
+	PatternFirstLastInitial NameEmailPattern = "first_last_initial"
+	// PatternInitials is the initials of every token of the name ("bfpm")
+	PatternInitials NameEmailPattern = "initials"
+	// PatternFirstName is the given name alone ("brayan")
+	PatternFirstName NameEmailPattern = "first"
+	// PatternLastName is a surname alone ("perez")
+	PatternLastName NameEmailPattern = "last"
+)
+
+// nameEmailPatternWeights is how strongly a local part following each pattern points to the name:
+// a full name is rarely chosen by chance, a given name alone or two initials often are
+var nameEmailPatternWeights = map[NameEmailPattern]float64{
+	PatternFullName:         1.0,
+	PatternFirstLast:        1.0,
+	PatternLastFirst:        1.0,
+	PatternInitialLast:      0.95,
+	PatternLastInitial:      0.95,
+	PatternFirstLastInitial: 0.9,
+	PatternInitials:         0.9,
+	PatternLastName:         0.8,
+	PatternFirstName:        0.7,
+}
+
+// shortInitialsWeight replaces the initials weight for names of two tokens, whose two-letter
+// initials ("jd") are shared by many people
+const shortInitialsWeight = 0.6
+
+// NameEmailResult is the structured outcome of a name-to-email consistency check. Score is in [0,1].
+type NameEmailResult struct {
+	Name   string   `json:"name"`
+	Email  string   `json:"email"`
+	Tokens []string `json:"tokens"`
+	// Local is the local part of the email without plus-address tag, digits and separators
+	Local string `json:"local"`
+	// Digits are the digits stripped from the local part ("87" in "brayan.perez87")
+	Digits string `json:"digits,omitempty"`
+	// Pattern and Candidate are the name-derived pattern closest to the local part and its spelling
+	Pattern   NameEmailPattern `json:"pattern,omitempty"`
+	Candidate string           `json:"candidate,omitempty"`
+	Score     float64          `json:"score"`
+}
+
+// nameEmailCandidate is a local part derived from a name by a pattern
+type nameEmailCandidate struct {
+	pattern NameEmailPattern
+	text    string
+}
+
+// CompareNameWithEmail scores how plausibly the local part of an email derives from a name with the
+// default matcher (see NameMatcher.CompareNameWithEmail)
+func CompareNameWithEmail(name, email string) NameEmailResult {
+	return defaultNameMatcher.CompareNameWithEmail(name, email)
+}
+
+// CompareNameWithEmail scores how plausibly the local part of an email derives from a name
+// ("brayan.perez87@", "bperez@", "perezb@" and "bfpm@" for "Brayan Ferney Perez Moreno"), a cheap
+// signal of synthetic identities when there is no second record to compare against.
+//
+// The name is parsed (see Parse) and local parts are generated from it by every pattern. Every
+// token after the given name may stand for the surname, as people pick either of their Spanish
+// surnames and parsing cannot tell a second given name from a first surname. The local part of the
+// email is normalized (see NormalizeName) without its plus-address tag, digits and separators, and
+// compared with every candidate using the Damerau-Levenshtein similarity, which unlike prefix-based
+// similarities does not let a given name alone pass for the full name. The score is the best
+// similarity times the weight of its pattern, but a candidate spelled exactly like the local part
+// always wins over near misses of weightier patterns: "john@" is the given name of "John Smith",
+// not a mistyped "johns".
+func (m *NameMatcher) CompareNameWithEmail(name, email string) NameEmailResult {
+	result := NameEmailResult{Name: name, Email: email}
+	local := strings.ToLower(SplitEmail(email).Local)
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	for _, r := range local {
+		if unicode.IsDigit(r) {
+			result.Digits += string(r)
+		}
+	}
+	result.Local = compactName(NormalizeName(local))
+
+	parsed := m.Parse(name)
+	tokens := parsed.matchTokens()
+	for _, token := range tokens {
+		result.Tokens = append(result.Tokens, token.text)
+	}
+	if result.Local == "" || len(tokens) == 0 {
+		return result
+	}
+
+	exact := false
+	for _, candidate := range nameEmailCandidates(tokens, parsed.Surname) {
+		weight := nameEmailPatternWeights[candidate.pattern]
+		if candidate.pattern == PatternInitials && len(tokens) < 3 {
+			weight = shortInitialsWeight
+		}
+		similarity := 1.0
+		if candidate.text != result.Local {
+			if exact {
+				continue
+			}
+			similarity = DamerauLevenshtein{}.Compare(candidate.text, result.Local)
+		}
+		if score := weight * similarity; score > result.Score || similarity == 1.0 && !exact {
+			result.Score, result.Pattern, result.Candidate = score, candidate.pattern, candidate.text
+			exact = similarity == 1.0
+		}
+	}
+	return result
+}
+
+// nameEmailCandidates generates the local parts a person could derive from the tokens of their name
+// and from their whole surname, particles included ("dasilva")
+func nameEmailCandidates(tokens []nameToken, surname []string) []nameEmailCandidate {
+	var texts, initials []string
+	for _, token := range tokens {
+		text := compactName(token.text)
+		texts = append(texts, text)
+		initials = append(initials, firstRune(text))
+	}
+	full := nameEmailCandidate{PatternFullName, strings.Join(texts, "")}
+	candidates := []nameEmailCandidate{{PatternFirstName, texts[0]}}
+	if len(tokens) == 1 {
+		return append(candidates, full)
+	}
+	candidates = append(candidates, nameEmailCandidate{PatternInitials, strings.Join(initials, "")})
+
+	// every token after the given name may be the surname, and so may the whole surname; each comes
+	// with the initials of the names before it, "bf" for "perez" in "Brayan Ferney Perez"
+	type surnameCandidate struct{ text, initials string }
+	var surnames []surnameCandidate
+	for i, text := range texts[1:] {
+		surnames = append(surnames, surnameCandidate{text, strings.Join(initials[:i+1], "")})
+	}
+	if len(surname) > 1 {
+		given := max(len(texts)-len(surname), 1)
+		surnames = append(surnames, surnameCandidate{compactName(strings.Join(surname, "")), strings.Join(initials[:given], "")})
+	}
+
+	first, initial := texts[0], initials[0]
+	for _, surname := range surnames {
+		candidates = append(candidates,
+			nameEmailCandidate{PatternFirstLast, first + surname.text},
+			nameEmailCandidate{PatternLastFirst, surname.text + first},
+			nameEmailCandidate{PatternInitialLast, initial + surname.text},
+			nameEmailCandidate{PatternInitialLast, surname.initials + surname.text},
+			nameEmailCandidate{PatternLastInitial, surname.text + initial},
+			nameEmailCandidate{PatternFirstLastInitial, first + firstRune(surname.text)},
+			nameEmailCandidate{PatternLastName, surname.text},
+		)
+	}
+	// last, so a two-token name reports first_last rather than full_name
+	return append(candidates, full)
+}
+
+// firstRune returns the first rune of s as a string
+func firstRune(s string) string {
+	_, size := utf8.DecodeRuneInString(s)
+	return s[:size]
+}
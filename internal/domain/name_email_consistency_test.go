@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+func TestCompareNameWithEmailPatterns(t *testing.T) {
+	name := "Brayan Ferney Perez Moreno"
+	tests := []struct {
+		email   string
+		pattern NameEmailPattern
+		score   float64
+	}{
+		{"brayan.perez87@gmail.com", PatternFirstLast, 1.0},
+		{"moreno.brayan@example.com", PatternLastFirst, 1.0},
+		{"bperez@example.com", PatternInitialLast, 0.95},
+		{"bfperez@example.com", PatternInitialLast, 0.95},
+		{"perezb@example.com", PatternLastInitial, 0.95},
+		{"brayanp@example.com", PatternFirstLastInitial, 0.9},
+		{"bfpm@example.com", PatternInitials, 0.9},
+		{"perez+shop@example.com", PatternLastName, 0.8},
+	}
+	for _, tt := range tests {
+		result := CompareNameWithEmail(name, tt.email)
+		if result.Pattern != tt.pattern || result.Score != tt.score {
+			t.Errorf("'%s' vs '%s': expected pattern %s with score %.2f, got %s with %.2f ('%s')",
+				name, tt.email, tt.pattern, tt.score, result.Pattern, result.Score, result.Candidate)
+		}
+	}
+}
+
+func TestCompareNameWithEmailPrefersExactCandidates(t *testing.T) {
+	// "johns" (first_last_initial) is one letter away, but "john" is the given name itself
+	result := CompareNameWithEmail("John Smith", "john@example.com")
+	if result.Pattern != PatternFirstName || result.Candidate != "john" || result.Score != 0.7 {
+		t.Errorf("Expected the exact given name to win, got %s with %.2f ('%s')", result.Pattern, result.Score, result.Candidate)
+	}
+}
+
+func TestCompareNameWithEmailStripsDigitsAndSeparators(t *testing.T) {
+	result := CompareNameWithEmail("José da Silva", "Jose_Da.Silva1985@example.com")
+	if result.Local != "josedasilva" || result.Digits != "1985" || result.Score != 1.0 {
+		t.Errorf("Expected 'josedasilva' with digits '1985' to score 1.0, got %+v", result)
+	}
+}
+
+func TestCompareNameWithEmailUnrelated(t *testing.T) {
+	for _, email := range []string{"xk7q9z@example.com", "john.smith@example.com", "bp@example.com"} {
+		if score := CompareNameWithEmail("Brayan Ferney Perez Moreno", email).Score; score >= 0.5 {
+			t.Errorf("Expected '%s' not to derive from the name, got %.2f", email, score)
+		}
+	}
+	if score := CompareNameWithEmail("John Smith", "").Score; score != 0.0 {
+		t.Errorf("Expected an empty email to score 0, got %.2f", score)
+	}
+}
@@ -0,0 +1,152 @@
+package domain
+
+// MatchRule identifies the comparison rule that decided a match score
+type MatchRule string
+
+const (
+	// RuleBothEmpty fires when both names are empty
+	RuleBothEmpty MatchRule = "both_empty"
+	// RuleOneEmpty fires when exactly one of the names is empty
+	RuleOneEmpty MatchRule = "one_empty"
+	// RuleFullNormalizedExact fires when both names are identical after normalization
+	RuleFullNormalizedExact MatchRule = "full_normalized_exact"
+	// RuleRomanizationExact fires when a CJK name and a Latin name are romanizations of the same
+	// name ("毛泽东" and "Mao Tse-tung")
+	RuleRomanizationExact MatchRule = "romanization_exact"
+	// RuleNoTokens fires when one of the names has no tokens left after tokenization
+	RuleNoTokens MatchRule = "no_tokens"
+	// RuleFirstLastExact fires when both given names and surnames match
+	RuleFirstLastExact MatchRule = "first_last_exact"
+	// RuleNicknameMatch fires when the given names are nickname variants of each other ("Bill" and
+	// "William") and the surnames match
+	RuleNicknameMatch MatchRule = "nickname_match"
+	// RuleInitialMatch fires when one given name is the initial of the other ("J. Smith" and
+	// "John Smith") and the surnames match
+	RuleInitialMatch MatchRule = "initial_match"
+	// RuleInitialsOnly fires when the names agree in nothing but initials ("A" and "Alice"), and
+	// caps the score far below any threshold
+	RuleInitialsOnly MatchRule = "initials_only"
+	// RuleDoubleSurname fires when names are compared under the Spanish double-surname convention
+	// and the given names and the paternal and maternal surnames decide the score
+	RuleDoubleSurname MatchRule = "double_surname"
+	// RuleFirstLastPositional fires when the weighted first and last name scores decide the score
+	RuleFirstLastPositional MatchRule = "first_last_positional"
+	// RuleMiddleTokenSweep fires when the optimal one-to-one alignment of the tokens of both names
+	// decides the score
+	RuleMiddleTokenSweep MatchRule = "middle_token_sweep"
+	// RuleOrganizationExact fires when two organization names have the same words once legal forms,
+	// stopwords and spaces are left out ("Acme Corp." and "ACME Corporation Ltd")
+	RuleOrganizationExact MatchRule = "organization_exact"
+	// RuleAcronym fires when one organization name is the acronym of the other ("IBM" and
+	// "International Business Machines")
+	RuleAcronym MatchRule = "acronym"
+	// RuleTokenSet fires when the overlap of the word sets of two organization names decides the score
+	RuleTokenSet MatchRule = "token_set"
+	// RuleCanonicalEmailExact fires when two email addresses reach the same mailbox once
+	// canonicalized ("j.o.h.n+promo@gmail.com" and "john@googlemail.com")
+	RuleCanonicalEmailExact MatchRule = "canonical_email_exact"
+	// RuleEmailComponents fires when the weighted scores of the local parts and the domains of two
+	// email addresses decide the score
+	RuleEmailComponents MatchRule = "email_components"
+	// RuleDateOfBirthExact fires when two dates of birth are the same date
+	RuleDateOfBirthExact MatchRule = "date_of_birth_exact"
+	// RuleDateOfBirthPartial fires when two dates of birth agree to the precision of the less
+	// precise one ("1985-03" and "1985-03-07")
+	RuleDateOfBirthPartial MatchRule = "date_of_birth_partial"
+	// RuleDateOfBirthTransposed fires when two dates of birth have day and month swapped
+	// ("1985-03-07" and "1985-07-03")
+	RuleDateOfBirthTransposed MatchRule = "date_of_birth_transposed"
+	// RuleDateOfBirthCentury fires when two dates of birth are a century apart ("1985" and "2085")
+	RuleDateOfBirthCentury MatchRule = "date_of_birth_century"
+	// RuleDateOfBirthYearOffByOne fires when two dates of birth are a year apart to the day
+	RuleDateOfBirthYearOffByOne MatchRule = "date_of_birth_year_off_by_one"
+	// RuleDateOfBirthDigitTypo fires when two dates of birth differ by one mistyped digit or two
+	// swapped adjacent digits ("1985-03-07" and "1958-03-07")
+	RuleDateOfBirthDigitTypo MatchRule = "date_of_birth_digit_typo"
+	// RuleDateOfBirthMismatch fires when two dates of birth are different dates
+	RuleDateOfBirthMismatch MatchRule = "date_of_birth_mismatch"
+	// RulePhoneExact fires when two phone numbers have the same digits
+	RulePhoneExact MatchRule = "phone_exact"
+	// RulePhoneNationalNumber fires when a national phone number ends an international one
+	// ("612345678" and "+33 6 12 34 56 78")
+	RulePhoneNationalNumber MatchRule = "phone_national_number"
+	// RulePhoneTypo fires when two phone numbers differ by one substituted or swapped digit
+	RulePhoneTypo MatchRule = "phone_typo"
+	// RulePhoneMismatch fires when two phone numbers are different numbers
+	RulePhoneMismatch MatchRule = "phone_mismatch"
+	// RuleAddressExact fires when two postal addresses are identical once abbreviations are expanded
+	// ("12 Main St." and "12 Main Street")
+	RuleAddressExact MatchRule = "address_exact"
+	// RuleAddressComponents fires when the words and the numbers of two postal addresses decide the score
+	RuleAddressComponents MatchRule = "address_components"
+	// RuleUnusableValue fires when a customer attribute holds a placeholder or a value that cannot be
+	// read ("N/A", "unknown") rather than data; customer comparisons treat the attribute as missing
+	RuleUnusableValue MatchRule = "unusable_value"
+	// RuleNationalIDExact fires when two national ID numbers are the same once separators are removed
+	RuleNationalIDExact MatchRule = "national_id_exact"
+	// RuleNationalIDMismatch fires when two national ID numbers differ
+	RuleNationalIDMismatch MatchRule = "national_id_mismatch"
+)
+
+// PhoneticCode holds the primary and alternate phonetic keys of a token
+type PhoneticCode struct {
+	Primary   string `json:"primary"`
+	Alternate string `json:"alternate"`
+}
+
+// PhoneticComparison holds the keys one phonetic encoder produced for a pair of tokens
+type PhoneticComparison struct {
+	Encoder string       `json:"encoder"`
+	Code1   PhoneticCode `json:"code1"`
+	Code2   PhoneticCode `json:"code2"`
+	Match   bool         `json:"match"`
+}
+
+// TokenComparison explains how two individual name tokens were compared
+type TokenComparison struct {
+	Token1            string               `json:"token1"`
+	Token2            string               `json:"token2"`
+	Algorithm         string               `json:"algorithm"`
+	Similarity        float64              `json:"similarity"`
+	Levenshtein       float64              `json:"levenshtein"`
+	Phonetic          []PhoneticComparison `json:"phonetic"`
+	PhoneticMatch     bool                 `json:"phonetic_match"`
+	ExactMatch        bool                 `json:"exact_match"`
+	RomanizationMatch bool                 `json:"romanization_match"`
+	NicknameMatch     bool                 `json:"nickname_match"`
+	InitialMatch      bool                 `json:"initial_match"`
+	SurnameMatch      SurnameMatch         `json:"surname_match,omitempty"`
+	Score             float64              `json:"score"`
+}
+
+// NameMatchResult is the structured outcome of a name comparison, including every
+// intermediate score needed to explain why two names did or did not match.
+// All scores are in [0,1]; Score is the component selected by Rule.
+type NameMatchResult struct {
+	Name1           string            `json:"name1"`
+	Name2           string            `json:"name2"`
+	Channel         string            `json:"channel,omitempty"`
+	Script1         string            `json:"script1"`
+	Script2         string            `json:"script2"`
+	Normalized1     string            `json:"normalized1"`
+	Normalized2     string            `json:"normalized2"`
+	Tokens1         []string          `json:"tokens1"`
+	Tokens2         []string          `json:"tokens2"`
+	Parsed1         ParsedName        `json:"parsed1"`
+	Parsed2         ParsedName        `json:"parsed2"`
+	Order1          NameOrder         `json:"order1"`
+	Order2          NameOrder         `json:"order2"`
+	OrderSwapped    bool              `json:"order_swapped"`
+	FirstName       *TokenComparison  `json:"first_name,omitempty"`
+	LastName        *TokenComparison  `json:"last_name,omitempty"`
+	MiddleTokens    []TokenComparison `json:"middle_tokens,omitempty"`
+	Unmatched1      []string          `json:"unmatched1,omitempty"`
+	Unmatched2      []string          `json:"unmatched2,omitempty"`
+	FirstNameScore  float64           `json:"first_name_score"`
+	LastNameScore   float64           `json:"last_name_score"`
+	PositionalScore float64           `json:"positional_score"`
+	MiddleScore     float64           `json:"middle_score"`
+	SuffixConflict  bool              `json:"suffix_conflict"`
+	Rule            MatchRule         `json:"rule"`
+	Score           float64           `json:"score"`
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+)
+
+// NameMatcher compares names with a fixed scoring config. Everything a comparison needs
+// (similarity algorithm, phonetic encoders, compiled patterns) is resolved once by NewNameMatcher,
+// so a single matcher should be built at start-up and shared; it is safe for concurrent use.
+type NameMatcher struct {
+	config           ScoringConfig
+	logger           *slog.Logger
+	trace            bool
+	similarityName   string
+	similarity       Similarity
+	phoneticEncoders []namedPhoneticEncoder
+	tokenSeparator   *regexp.Regexp
+	nicknamePattern  *regexp.Regexp
+	nicknames        *NicknameDictionary
+	frequencies      *TokenFrequencies
+	typoCosts        map[string]*SubstitutionCosts
+	// emailLocalSimilarity scores the local parts of email addresses
+	emailLocalSimilarity Similarity
+	disposableDomains    *DisposableDomains
+	fieldComparators     map[CustomerField]FieldComparator
+}
+
+// namedPhoneticEncoder is a phonetic encoder with the name it was configured under
+type namedPhoneticEncoder struct {
+	name    string
+	encoder PhoneticEncoder
+}
+
+// defaultNameMatcher backs the package-level comparison functions
+var defaultNameMatcher = NewNameMatcher(DefaultScoringConfig())
+
+// MatcherOption customizes a NameMatcher
+type MatcherOption func(*NameMatcher)
+
+// WithLogger sets the logger used by the matcher. Without it the matcher logs nothing.
+func WithLogger(logger *slog.Logger) MatcherOption {
+	return func(m *NameMatcher) {
+		m.logger = logger
+	}
+}
+
+// WithDebugTrace enables or disables the debug trace, which logs every normalization, token
+// comparison and phonetic key at debug level. The trace contains the names being compared,
+// so it must not be enabled where customer names may not be logged.
+func WithDebugTrace(enabled bool) MatcherOption {
+	return func(m *NameMatcher) {
+		m.trace = enabled
+	}
+}
+
+// WithNicknameDictionary sets the dictionary of given-name variants the matcher consults.
+// Without it the matcher uses DefaultNicknameDictionary; nil disables nickname matching.
+func WithNicknameDictionary(nicknames *NicknameDictionary) MatcherOption {
+	return func(m *NameMatcher) {
+		m.nicknames = nicknames
+	}
+}
+
+// WithTokenFrequencies sets the token frequency model that scales the agreement of every token
+// to a comparison, so matches on rare names count more than matches on common ones; mismatches count
+// in full whatever the token (see evidenceAverage). Without it, or with nil, every token counts the same.
+func WithTokenFrequencies(frequencies *TokenFrequencies) MatcherOption {
+	return func(m *NameMatcher) {
+		m.frequencies = frequencies
+	}
+}
+
+// NewNameMatcher creates a NameMatcher that scores with the given config. An unknown similarity
+// algorithm falls back to Levenshtein (Jaro-Winkler and Damerau-Levenshtein for the components of
+// email addresses) and unknown phonetic encoders are skipped; use
+// ScoringConfig.Validate to reject such configs up front.
+func NewNameMatcher(config ScoringConfig, opts ...MatcherOption) *NameMatcher {
+	m := &NameMatcher{
+		config:            config,
+		logger:            slog.New(discardHandler{}),
+		similarityName:    config.Similarity,
+		tokenSeparator:    nameSeparatorPattern,
+		nicknamePattern:   nicknamePattern,
+		nicknames:         DefaultNicknameDictionary(),
+		disposableDomains: DefaultDisposableDomains(),
+	}
+	m.fieldComparators = m.defaultFieldComparators()
+
+	similarity, err := SimilarityByName(config.Similarity)
+	if err != nil || config.Similarity == "" {
+		similarity, m.similarityName = Levenshtein{}, SimilarityLevenshtein
+	}
+	m.similarity = similarity
+	if m.emailLocalSimilarity, err = SimilarityByName(config.EmailLocalSimilarity); err != nil {
+		m.emailLocalSimilarity = JaroWinkler{PrefixScale: 0.1, BoostThreshold: 0.7}
+	}
+
+	for _, name := range config.PhoneticEncoders {
+		if encoder, err := PhoneticEncoderByName(name); err == nil {
+			m.phoneticEncoders = append(m.phoneticEncoders, namedPhoneticEncoder{name: name, encoder: encoder})
+		}
+	}
+
+	for channel, tables := range config.ChannelTypoCosts {
+		var costs *SubstitutionCosts
+		for _, name := range tables {
+			if table, err := SubstitutionCostsByName(name); err == nil {
+				costs = table.Merge(costs)
+			}
+		}
+		if costs != nil {
+			if m.typoCosts == nil {
+				m.typoCosts = map[string]*SubstitutionCosts{}
+			}
+			m.typoCosts[channel] = costs
+		}
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Normalize standardizes a name the way the matcher does before comparing it (see NormalizeName)
+func (m *NameMatcher) Normalize(name string) string {
+	return NormalizeName(name)
+}
+
+// Tokenize splits a name into the normalized tokens the matcher compares (see TokenizeName)
+func (m *NameMatcher) Tokenize(name string) []string {
+	return tokenizeName(name, m.tokenSeparator)
+}
+
+// DefaultNameMatcher returns the shared matcher that uses DefaultScoringConfig and logs nothing
+func DefaultNameMatcher() *NameMatcher {
+	return defaultNameMatcher
+}
+
+// Nicknames returns the nickname dictionary of the matcher, or nil when nickname matching is disabled
+func (m *NameMatcher) Nicknames() *NicknameDictionary {
+	return m.nicknames
+}
+
+// TokenFrequencies returns the token frequency model of the matcher, or nil when tokens are not weighted by frequency
+func (m *NameMatcher) TokenFrequencies() *TokenFrequencies {
+	return m.frequencies
+}
+
+// TypoCosts returns the substitution costs of the typos expected from an input channel, or nil
+// when the channel has no typo cost tables
+func (m *NameMatcher) TypoCosts(channel string) *SubstitutionCosts {
+	return m.typoCosts[channel]
+}
+
+// Config returns the scoring config of the matcher
+func (m *NameMatcher) Config() ScoringConfig {
+	return m.config
+}
+
+// tracing reports whether debug trace records should be built
+func (m *NameMatcher) tracing() bool {
+	return m.trace && m.logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// traceDebug logs a debug trace record when the trace is enabled
+func (m *NameMatcher) traceDebug(msg string, attrs ...slog.Attr) {
+	if m.tracing() {
+		m.logger.LogAttrs(context.Background(), slog.LevelDebug, msg, attrs...)
+	}
+}
+
+// discardHandler is a slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func newBufferLogger(level slog.Level) (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	return slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: level})), &buf
+}
+
+func TestNameMatcherLogsWithoutNames(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelDebug)
+	matcher := NewNameMatcher(DefaultScoringConfig(), WithLogger(logger))
+	matcher.Compare("Brayan Ferney Perez Moreno", "Ferney Perez")
+
+	output := buf.String()
+	if !strings.Contains(output, `"msg":"name comparison"`) || !strings.Contains(output, `"rule":"middle_token_sweep"`) {
+		t.Errorf("Expected a debug record with the rule and score, got %s", output)
+	}
+	if strings.Contains(strings.ToLower(output), "ferney") {
+		t.Errorf("Expected no customer names in the log without debug trace, got %s", output)
+	}
+}
+
+func TestNameMatcherDebugTrace(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelDebug)
+	matcher := NewNameMatcher(DefaultScoringConfig(), WithLogger(logger), WithDebugTrace(true))
+	matcher.Compare("Perez Brayan", "Peres Brayan")
+
+	output := buf.String()
+	for _, want := range []string{`"msg":"normalized names"`, `"msg":"tokenized names"`, `"msg":"token comparison"`, `"token1":"perez"`, `"encoder":"metaphone3"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected the debug trace to contain %s, got %s", want, output)
+		}
+	}
+}
+
+func TestNameMatcherDebugTraceRespectsLevel(t *testing.T) {
+	logger, buf := newBufferLogger(slog.LevelInfo)
+	matcher := NewNameMatcher(DefaultScoringConfig(), WithLogger(logger), WithDebugTrace(true))
+	matcher.Compare("Perez Brayan", "Peres Brayan")
+
+	if buf.Len() != 0 {
+		t.Errorf("Expected no debug records at info level, got %s", buf.String())
+	}
+}
+
+func TestNameMatcherTokenizeAndNormalize(t *testing.T) {
+	matcher := NewNameMatcher(DefaultScoringConfig())
+
+	if got := matcher.Normalize("José  O'Conner"); got != "jose  o conner" {
+		t.Errorf("Expected normalized name 'jose  o conner', got '%s'", got)
+	}
+	tokens := matcher.Tokenize("Brayan@#Ferney Perez-Moreno")
+	want := []string{"brayan", "ferney", "perez", "moreno"}
+	if strings.Join(tokens, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected tokens %v, got %v", want, tokens)
+	}
+}
+
+func TestNameMatcherIsSafeForConcurrentUse(t *testing.T) {
+	config := DefaultScoringConfig()
+	config.PhoneticEncoders = []string{PhoneticMetaphone3, PhoneticDoubleMetaphone}
+	matcher := NewNameMatcher(config)
+	want := matcher.Compare("Brayan Ferney Perez Moreno", "Brayan Peres").Score
+
+	done := make(chan float64)
+	for i := 0; i < 8; i++ {
+		go func() {
+			score := 0.0
+			for j := 0; j < 50; j++ {
+				score = matcher.Compare("Brayan Ferney Perez Moreno", "Brayan Peres").Score
+			}
+			done <- score
+		}()
+	}
+	for i := 0; i < 8; i++ {
+		if got := <-done; got != want {
+			t.Errorf("Expected concurrent comparisons to score %.2f, got %.2f", want, got)
+		}
+	}
+}
+
+func TestNameMatcherFallsBackForUnknownAlgorithms(t *testing.T) {
+	config := DefaultScoringConfig()
+	config.Similarity = "unknown"
+	config.PhoneticEncoders = []string{"unknown", PhoneticSoundex}
+	result := NewNameMatcher(config).Compare("Perez", "Peres")
+
+	if result.FirstName.Algorithm != SimilarityLevenshtein {
+		t.Errorf("Expected fallback to %s, got %s", SimilarityLevenshtein, result.FirstName.Algorithm)
+	}
+	if len(result.FirstName.Phonetic) != 1 || result.FirstName.Phonetic[0].Encoder != PhoneticSoundex {
+		t.Errorf("Expected only the soundex encoder to be used, got %+v", result.FirstName.Phonetic)
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "strings"
+
+// NameOrder is the order in which the given name and the surname of a name are written
+type NameOrder string
+
+const (
+	// OrderAuto lets the parser decide: names written in a CJK script are read family name first,
+	// everything else given name first
+	OrderAuto NameOrder = ""
+	// OrderGivenFirst reads the first token as the given name and the last as the surname ("Yuki Matsuda")
+	OrderGivenFirst NameOrder = "given_first"
+	// OrderFamilyFirst reads the first token as the surname ("Matsuda Yuki", "Nagy János")
+	OrderFamilyFirst NameOrder = "family_first"
+)
+
+// familyFirstLanguages are the languages whose names are written family name first
+var familyFirstLanguages = map[string]bool{
+	"hu": true, "ja": true, "zh": true, "ko": true, "vi": true, "km": true, "mn": true,
+}
+
+// NameOrderForCulture returns the name order expected for a culture given as a language tag
+// ("hu", "ja-JP", "zh_Hant"). An empty culture leaves the order to the parser.
+func NameOrderForCulture(culture string) NameOrder {
+	switch language := cultureLanguage(culture); {
+	case language == "":
+		return OrderAuto
+	case familyFirstLanguages[language]:
+		return OrderFamilyFirst
+	default:
+		return OrderGivenFirst
+	}
+}
+
+// Reversed returns the opposite order. OrderAuto has no opposite and is returned unchanged.
+func (o NameOrder) Reversed() NameOrder {
+	switch o {
+	case OrderGivenFirst:
+		return OrderFamilyFirst
+	case OrderFamilyFirst:
+		return OrderGivenFirst
+	default:
+		return o
+	}
+}
+
+// cultureLanguage returns the lowercase language subtag of a culture ("ja" for "ja_JP")
+func cultureLanguage(culture string) string {
+	language, _, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(culture), "_", "-"), "-")
+	return strings.ToLower(language)
+}
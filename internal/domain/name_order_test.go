@@ -0,0 +1,66 @@
+package domain
+
+import "testing"
+
+func TestNameOrderForCulture(t *testing.T) {
+	tests := map[string]NameOrder{
+		"":      OrderAuto,
+		"hu":    OrderFamilyFirst,
+		"ja-JP": OrderFamilyFirst,
+		"zh_TW": OrderFamilyFirst,
+		"en-US": OrderGivenFirst,
+		"es":    OrderGivenFirst,
+	}
+	for culture, want := range tests {
+		if got := NameOrderForCulture(culture); got != want {
+			t.Errorf("NameOrderForCulture('%s') = '%s', want '%s'", culture, got, want)
+		}
+	}
+}
+
+func TestParseInOrder(t *testing.T) {
+	parsed := DefaultNameMatcher().ParseInOrder("Nagy János Péter", OrderFamilyFirst)
+	if parsed.SurnameString() != "nagy" || parsed.Given != "janos" || len(parsed.Middle) != 1 {
+		t.Errorf("Expected surname 'nagy' and given name 'janos', got %+v", parsed)
+	}
+	if parsed := DefaultNameMatcher().ParseInOrder("Nagy, János", OrderGivenFirst); parsed.SurnameString() != "nagy" {
+		t.Errorf("Expected the comma to override the order, got %+v", parsed)
+	}
+}
+
+func TestCompareNamesInSwappedOrder(t *testing.T) {
+	pairs := [][2]string{
+		{"Matsuda Yuki", "Yuki Matsuda"},
+		{"Nagy János", "János Nagy"},
+		{"Nagy János Péter", "János Péter Nagy"},
+	}
+	for _, pair := range pairs {
+		result := CompareNamesDetailed(pair[0], pair[1])
+		if result.Score != 1.0 || result.Rule != RuleFirstLastExact || !result.OrderSwapped {
+			t.Errorf("'%s' vs '%s': expected a swapped first/last exact match, got %.2f (%s)", pair[0], pair[1], result.Score, result.Rule)
+		}
+	}
+
+	if result := CompareNamesDetailed("John Smith", "John Smith-Jones"); result.OrderSwapped {
+		t.Errorf("Expected names in the same order not to be swapped, got %+v", result)
+	}
+}
+
+func TestCompareNamesWithCultureHint(t *testing.T) {
+	matcher := DefaultNameMatcher()
+	hint := CompareOptions{Order1: NameOrderForCulture("hu"), Order2: OrderGivenFirst}
+	result := matcher.CompareWithOptions("Nagy János", "János Nagy", hint)
+	if result.Score != 1.0 || result.OrderSwapped || result.Order1 != OrderFamilyFirst {
+		t.Errorf("Expected the culture hint to read the first name family name first, got %+v", result)
+	}
+
+	config := DefaultScoringConfig()
+	config.OrderInvariant = false
+	strict := NewNameMatcher(config)
+	if result := strict.Compare("Nagy János", "János Nagy"); result.Rule == RuleFirstLastExact {
+		t.Errorf("Expected swapped names not to align positionally without order invariance, got %s", result.Rule)
+	}
+	if result := strict.CompareWithOptions("Nagy János", "János Nagy", hint); result.Rule != RuleFirstLastExact {
+		t.Errorf("Expected the culture hint to align the names without order invariance, got %s", result.Rule)
+	}
+}
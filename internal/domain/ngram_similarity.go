@@ -0,0 +1,68 @@
+package domain
+
+// NGramJaccard is the Jaccard index of the character n-gram sets of two strings.
+// Strings are padded so that short names still produce n-grams; N defaults to 2.
+type NGramJaccard struct {
+	N int
+}
+
+// Compare returns the n-gram Jaccard similarity of a and b
+func (s NGramJaccard) Compare(a, b string) float64 {
+	grams1, grams2 := characterNGrams(a, s.N), characterNGrams(b, s.N)
+	if len(grams1) == 0 && len(grams2) == 0 {
+		return 1.0
+	}
+	shared := sharedNGrams(grams1, grams2)
+	return float64(shared) / float64(len(grams1)+len(grams2)-shared)
+}
+
+// NGramDice is the Sørensen-Dice coefficient of the character n-gram sets of two strings.
+// Strings are padded so that short names still produce n-grams; N defaults to 2.
+type NGramDice struct {
+	N int
+}
+
+// Compare returns the n-gram Dice similarity of a and b
+func (s NGramDice) Compare(a, b string) float64 {
+	grams1, grams2 := characterNGrams(a, s.N), characterNGrams(b, s.N)
+	if len(grams1) == 0 && len(grams2) == 0 {
+		return 1.0
+	}
+	return 2.0 * float64(sharedNGrams(grams1, grams2)) / float64(len(grams1)+len(grams2))
+}
+
+// characterNGrams returns the set of character n-grams of s, padded with n-1 boundary markers on each side
+func characterNGrams(s string, n int) map[string]struct{} {
+	if n <= 0 {
+		n = 2
+	}
+	grams := map[string]struct{}{}
+	if s == "" {
+		return grams
+	}
+
+	padded := make([]rune, 0, len(s)+2*(n-1))
+	for i := 0; i < n-1; i++ {
+		padded = append(padded, '\x00')
+	}
+	padded = append(padded, []rune(s)...)
+	for i := 0; i < n-1; i++ {
+		padded = append(padded, '\x00')
+	}
+
+	for i := 0; i+n <= len(padded); i++ {
+		grams[string(padded[i:i+n])] = struct{}{}
+	}
+	return grams
+}
+
+// sharedNGrams counts the n-grams present in both sets
+func sharedNGrams(grams1, grams2 map[string]struct{}) int {
+	shared := 0
+	for gram := range grams1 {
+		if _, ok := grams2[gram]; ok {
+			shared++
+		}
+	}
+	return shared
+}
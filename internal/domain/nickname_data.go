@@ -0,0 +1,172 @@
+package domain
+
+// bundledNicknameGroups are the given-name variant groups of DefaultNicknameDictionary.
+// Each group lists a formal name followed by its common nicknames, diminutives and spellings.
+var bundledNicknameGroups = [][]string{
+	// English
+	{"william", "bill", "billy", "will", "willy", "willie", "liam"},
+	{"robert", "bob", "bobby", "rob", "robbie", "bert"},
+	{"richard", "rick", "ricky", "rich", "richie", "dick"},
+	{"james", "jim", "jimmy", "jamie"},
+	{"john", "jack", "johnny", "jon"},
+	{"jonathan", "jon", "jonny"},
+	{"nathaniel", "nathan", "nat", "nate"},
+	{"michael", "mike", "mikey", "mick", "mickey"},
+	{"thomas", "tom", "tommy"},
+	{"charles", "charlie", "chuck", "chas"},
+	{"edward", "ed", "eddie", "ted", "ned"},
+	{"anthony", "tony"},
+	{"joseph", "joe", "joey"},
+	{"daniel", "dan", "danny"},
+	{"david", "dave", "davy"},
+	{"christopher", "chris", "kit"},
+	{"matthew", "matt"},
+	{"andrew", "andy", "drew"},
+	{"nicholas", "nick", "nicky"},
+	{"samuel", "sam", "sammy"},
+	{"benjamin", "ben", "benny"},
+	{"alexander", "alex", "al", "xander", "sandy"},
+	{"patrick", "pat", "paddy"},
+	{"stephen", "steven", "steve"},
+	{"timothy", "tim", "timmy"},
+	{"gregory", "greg"},
+	{"peter", "pete"},
+	{"henry", "harry", "hank"},
+	{"frederick", "fred", "freddie"},
+	{"lawrence", "larry"},
+	{"theodore", "ted", "teddy", "theo"},
+	{"albert", "al", "bert"},
+	{"kenneth", "ken", "kenny"},
+	{"donald", "don", "donnie"},
+	{"ronald", "ron", "ronnie"},
+	{"raymond", "ray"},
+	{"gerald", "jerry", "gerry"},
+	{"walter", "walt", "wally"},
+	{"francis", "frank", "frankie"},
+	{"zachary", "zach", "zack"},
+	{"elizabeth", "liz", "lizzie", "beth", "betty", "betsy", "eliza", "lisa", "libby"},
+	{"margaret", "maggie", "meg", "peggy", "marge", "margie"},
+	{"katherine", "catherine", "kathryn", "kate", "katie", "kathy", "cathy", "kat", "kitty"},
+	{"patricia", "pat", "patty", "trish"},
+	{"susan", "sue", "susie"},
+	{"deborah", "debbie", "deb"},
+	{"jennifer", "jen", "jenny"},
+	{"rebecca", "becky", "becca"},
+	{"victoria", "vicky", "tori"},
+	{"abigail", "abby"},
+	{"dorothy", "dot", "dottie"},
+	{"barbara", "barb", "babs"},
+	{"samantha", "sam", "sammy"},
+	{"jessica", "jess", "jessie"},
+
+	// Spanish
+	{"jose", "pepe", "pepito", "chepe", "chema"},
+	{"josefa", "pepa", "pepita"},
+	{"francisco", "paco", "pancho", "curro", "quico", "fran"},
+	{"ignacio", "nacho"},
+	{"manuel", "manolo", "manu", "lolo"},
+	{"guadalupe", "lupe", "lupita"},
+	{"dolores", "lola", "loli"},
+	{"jesus", "chucho", "chuy"},
+	{"enrique", "quique"},
+	{"concepcion", "concha", "conchita"},
+	{"rosario", "charo", "chayo"},
+	{"antonio", "tono", "toni"},
+	{"alejandro", "alex", "ale", "jandro"},
+	{"roberto", "beto", "tito"},
+	{"alberto", "beto"},
+	{"eduardo", "lalo", "edu"},
+	{"gonzalo", "chalo"},
+	{"luis", "lucho"},
+	{"rafael", "rafa"},
+	{"ricardo", "richi"},
+	{"guillermo", "memo", "guille"},
+	{"santiago", "santi", "chago"},
+	{"isabel", "isa", "chabela"},
+	{"mercedes", "meche", "merche"},
+	{"teresa", "tere"},
+	{"fernando", "nando", "fer"},
+	{"federico", "fede"},
+	{"pilar", "pili"},
+	{"consuelo", "chelo"},
+	{"graciela", "chela"},
+
+	// Portuguese
+	{"antonio", "toninho", "tonho"},
+	{"jose", "ze", "zezinho", "juca"},
+	{"francisco", "chico", "xico"},
+	{"joao", "joaozinho"},
+	{"sebastiao", "tiao"},
+	{"luiz", "luis", "lula"},
+	{"eduardo", "dudu"},
+	{"carlos", "carlinhos", "cacau"},
+	{"paulo", "paulinho"},
+	{"ricardo", "cadu"},
+	{"rodrigo", "digo"},
+	{"gabriela", "gabi"},
+	{"fernanda", "nanda"},
+	{"isabela", "bela", "isa"},
+	{"manoel", "manuel", "mane", "neco"},
+	{"beatriz", "bia"},
+	{"ana", "aninha"},
+
+	// Russian
+	{"aleksandr", "alexandr", "alexander", "sasha", "sanya", "shura"},
+	{"aleksandra", "alexandra", "sasha", "shura"},
+	{"aleksei", "alexei", "alexey", "alyosha", "lyosha"},
+	{"mikhail", "misha"},
+	{"dmitry", "dmitri", "dmitrii", "dima", "mitya"},
+	{"nikolai", "nikolay", "kolya"},
+	{"vladimir", "volodya", "vova"},
+	{"ivan", "vanya"},
+	{"sergei", "sergey", "seryozha", "serezha"},
+	{"yevgeny", "evgeny", "evgeniy", "zhenya"},
+	{"pavel", "pasha"},
+	{"boris", "borya"},
+	{"konstantin", "kostya"},
+	{"yuri", "yury", "yura"},
+	{"viktor", "victor", "vitya"},
+	{"grigory", "grigori", "grisha"},
+	{"ekaterina", "yekaterina", "katerina", "katya", "katia"},
+	{"elena", "yelena", "lena"},
+	{"natalia", "natalya", "natasha"},
+	{"anastasia", "nastya", "nastia"},
+	{"maria", "mariya", "masha", "marusya"},
+	{"anna", "anya", "nyura"},
+	{"tatiana", "tatyana", "tanya"},
+	{"olga", "olya"},
+	{"svetlana", "sveta"},
+
+	// Italian
+	{"giuseppe", "peppe", "beppe", "pino", "peppino"},
+	{"giovanni", "gianni", "vanni", "nanni"},
+	{"francesco", "franco", "checco", "cecco"},
+	{"salvatore", "toto", "turi", "salvo"},
+	{"antonio", "tonino", "tonio"},
+	{"vincenzo", "enzo"},
+	{"domenico", "mimmo"},
+	{"alessandro", "sandro", "ale"},
+	{"lorenzo", "renzo"},
+	{"luigi", "gino"},
+	{"caterina", "rina"},
+	{"elisabetta", "betta", "elisa"},
+	{"giovanna", "gianna", "vanna"},
+	{"teresa", "tessa"},
+
+	// German
+	{"johann", "johannes", "hans", "hannes"},
+	{"wolfgang", "wolf", "wolfi"},
+	{"friedrich", "fritz"},
+	{"heinrich", "heinz", "heini"},
+	{"wilhelm", "willi", "willy"},
+	{"nikolaus", "klaus"},
+	{"elisabeth", "liese", "lieschen", "else", "elsbeth"},
+	{"margarete", "grete", "gretchen", "gretel"},
+	{"katharina", "kathe", "kaethe"},
+	{"josef", "joseph", "sepp", "seppl"},
+	{"georg", "jorg", "joerg"},
+	{"ludwig", "lutz"},
+	{"bernhard", "bernd"},
+	{"gerhard", "gerd"},
+	{"dietrich", "dieter"},
+}
@@ -0,0 +1,93 @@
+package domain
+
+import "slices"
+
+// NicknameDictionary knows which given names are nicknames, diminutives or variants of each other.
+// Names are grouped into equivalence groups; two names are equivalent when they share a group.
+// A name may belong to several groups ("sasha" is short for both "aleksandr" and "aleksandra").
+// A NicknameDictionary is immutable and safe for concurrent use.
+type NicknameDictionary struct {
+	groups  map[string][]int
+	members [][]string
+}
+
+// NewNicknameDictionary creates a dictionary from groups of equivalent names. Names are normalized
+// like the names being compared, so "José" and "jose" are the same entry.
+func NewNicknameDictionary(groups [][]string) *NicknameDictionary {
+	return (&NicknameDictionary{groups: map[string][]int{}}).Extend(groups)
+}
+
+// DefaultNicknameDictionary returns the bundled dictionary of English, Spanish, Portuguese,
+// Russian, Italian and German given-name variants
+func DefaultNicknameDictionary() *NicknameDictionary {
+	return defaultNicknameDictionary
+}
+
+// defaultNicknameDictionary is built once from the bundled nickname groups
+var defaultNicknameDictionary = NewNicknameDictionary(bundledNicknameGroups)
+
+// Extend returns a new dictionary with the groups of d plus the given groups
+func (d *NicknameDictionary) Extend(groups [][]string) *NicknameDictionary {
+	extended := &NicknameDictionary{groups: make(map[string][]int, len(d.groups)), members: d.members[:len(d.members):len(d.members)]}
+	for name, ids := range d.groups {
+		extended.groups[name] = ids
+	}
+	for _, group := range groups {
+		id := len(extended.members)
+		var members []string
+		for _, name := range group {
+			normalized := NormalizeName(name)
+			if normalized == "" || slices.Contains(extended.groups[normalized], id) {
+				continue
+			}
+			// Copy before appending so d keeps its own slices
+			ids := extended.groups[normalized]
+			extended.groups[normalized] = append(ids[:len(ids):len(ids)], id)
+			members = append(members, normalized)
+		}
+		if len(members) > 0 {
+			extended.members = append(extended.members, members)
+		}
+	}
+	return extended
+}
+
+// Equivalent reports whether two different names are variants of each other. Names are
+// expected in normalized form, as produced by NameMatcher.Tokenize.
+func (d *NicknameDictionary) Equivalent(name1, name2 string) bool {
+	if d == nil || name1 == name2 {
+		return false
+	}
+	for _, id := range d.groups[name1] {
+		if slices.Contains(d.groups[name2], id) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether a normalized name belongs to any group of the dictionary
+func (d *NicknameDictionary) Contains(name string) bool {
+	return d != nil && len(d.groups[name]) > 0
+}
+
+// Variants returns the other names sharing a group with a normalized name
+func (d *NicknameDictionary) Variants(name string) []string {
+	if d == nil {
+		return nil
+	}
+	var variants []string
+	for _, id := range d.groups[name] {
+		for _, member := range d.members[id] {
+			if member != name && !slices.Contains(variants, member) {
+				variants = append(variants, member)
+			}
+		}
+	}
+	return variants
+}
+
+// Len returns the number of equivalence groups in the dictionary
+func (d *NicknameDictionary) Len() int {
+	return len(d.members)
+}
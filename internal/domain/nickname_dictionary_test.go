@@ -0,0 +1,69 @@
+package domain
+
+import "testing"
+
+func TestNicknameDictionaryEquivalent(t *testing.T) {
+	dictionary := DefaultNicknameDictionary()
+	tests := []struct {
+		name1, name2 string
+		want         bool
+	}{
+		{"bill", "william", true},
+		{"pepe", "jose", true},
+		{"sasha", "aleksandr", true},
+		{"sasha", "aleksandra", true},
+		{"zhenya", "evgeny", true},
+		{"beppe", "giuseppe", true},
+		{"fritz", "friedrich", true},
+		{"chico", "francisco", true},
+		{"aleksandr", "aleksandra", false},
+		{"bill", "robert", false},
+		{"nathan", "jonathan", false},
+		{"nathan", "jon", false},
+		{"william", "william", false},
+	}
+	for _, tt := range tests {
+		if got := dictionary.Equivalent(tt.name1, tt.name2); got != tt.want {
+			t.Errorf("Equivalent('%s', '%s') = %v, want %v", tt.name1, tt.name2, got, tt.want)
+		}
+	}
+}
+
+func TestNicknameDictionaryExtend(t *testing.T) {
+	base := NewNicknameDictionary([][]string{{"William", "Bill"}})
+	extended := base.Extend([][]string{{"Bartholomew", "Bart"}, {"Jürgen", "Jupp"}})
+
+	if !extended.Equivalent("bart", "bartholomew") || !extended.Equivalent("jurgen", "jupp") || !extended.Equivalent("bill", "william") {
+		t.Errorf("Expected the extended dictionary to contain the old and new groups")
+	}
+	if base.Equivalent("bart", "bartholomew") || base.Len() != 1 || extended.Len() != 3 {
+		t.Errorf("Expected Extend to leave the original dictionary unchanged")
+	}
+	if !extended.Contains("jupp") || base.Contains("jupp") || extended.Contains("smith") {
+		t.Errorf("Expected Contains to report the names of the groups only")
+	}
+}
+
+func TestCompareNamesNicknameMatch(t *testing.T) {
+	pairs := [][2]string{
+		{"Bill Gates", "William Gates"},
+		{"Pepe Ramirez", "José Ramírez"},
+		{"Sasha Petrov", "Aleksandr Petrov"},
+	}
+	for _, pair := range pairs {
+		result := CompareNamesDetailed(pair[0], pair[1])
+		if result.Rule != RuleNicknameMatch || result.FirstName == nil || !result.FirstName.NicknameMatch {
+			t.Errorf("'%s' vs '%s': expected rule %s, got %+v", pair[0], pair[1], RuleNicknameMatch, result)
+		}
+		if result.Score < 0.9 {
+			t.Errorf("'%s' vs '%s': expected a score of at least 0.90, got %.2f", pair[0], pair[1], result.Score)
+		}
+	}
+}
+
+func TestNameMatcherWithoutNicknames(t *testing.T) {
+	matcher := NewNameMatcher(DefaultScoringConfig(), WithNicknameDictionary(nil))
+	if result := matcher.Compare("Bill Gates", "William Gates"); result.Rule == RuleNicknameMatch || result.Score >= 0.9 {
+		t.Errorf("Expected no nickname match without a dictionary, got %+v", result)
+	}
+}
@@ -18,11 +18,12 @@ func RemoveDiacritics(input string) string {
 	return t
 }
 
-// NormalizeName standardizes the name by converting it to lowercase, removing diacritics,
-// and replacing special characters (except hyphens) with spaces. Apostrophes are removed and joined.
+// NormalizeName standardizes the name by converting it to lowercase, romanizing non-Latin scripts
+// (see Transliterate), removing diacritics, and replacing special characters (except hyphens) with spaces.
+// Apostrophes are removed and joined.
 func NormalizeName(name string) string {
-	// Convert to lowercase and remove diacritics
-	normalized := strings.ToLower(RemoveDiacritics(name))
+	// Convert to lowercase, romanize and remove diacritics
+	normalized := RemoveDiacritics(Transliterate(strings.ToLower(name)))
 
 	// Replace all special characters (except hyphens) with spaces
 	var sb strings.Builder
@@ -0,0 +1,87 @@
+package domain
+
+import "strings"
+
+// NYSIIS is the New York State Identification and Intelligence System encoder.
+// Keys are truncated to six characters, as in the original algorithm.
+type NYSIIS struct{}
+
+// nysiisPrefixes and nysiisSuffixes are the first-step replacements applied to the whole name
+var (
+	nysiisPrefixes = [][2]string{{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"}}
+	nysiisSuffixes = [][2]string{{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"}}
+)
+
+// Encode returns the NYSIIS key of name
+func (NYSIIS) Encode(name string) (string, string) {
+	word := string(asciiLetters(name))
+	if word == "" {
+		return "", ""
+	}
+
+	for _, prefix := range nysiisPrefixes {
+		if strings.HasPrefix(word, prefix[0]) {
+			word = prefix[1] + word[len(prefix[0]):]
+			break
+		}
+	}
+	for _, suffix := range nysiisSuffixes {
+		if strings.HasSuffix(word, suffix[0]) {
+			word = word[:len(word)-len(suffix[0])] + suffix[1]
+			break
+		}
+	}
+
+	isVowel := func(c byte) bool { return strings.IndexByte("AEIOU", c) >= 0 }
+	chars := []byte(word)
+	key := []byte{chars[0]}
+	for i := 1; i < len(chars); i++ {
+		var replacement []byte
+		switch c := chars[i]; {
+		case c == 'E' && i+1 < len(chars) && chars[i+1] == 'V':
+			replacement = []byte("AF")
+		case isVowel(c):
+			replacement = []byte("A")
+		case c == 'Q':
+			replacement = []byte("G")
+		case c == 'Z':
+			replacement = []byte("S")
+		case c == 'M':
+			replacement = []byte("N")
+		case c == 'K' && i+1 < len(chars) && chars[i+1] == 'N':
+			replacement = []byte("N")
+		case c == 'K':
+			replacement = []byte("C")
+		case c == 'S' && i+2 < len(chars) && chars[i+1] == 'C' && chars[i+2] == 'H':
+			replacement = []byte("SSS")
+		case c == 'P' && i+1 < len(chars) && chars[i+1] == 'H':
+			replacement = []byte("FF")
+		case c == 'H' && (!isVowel(chars[i-1]) || (i+1 < len(chars) && !isVowel(chars[i+1])) || i+1 == len(chars)):
+			replacement = []byte{chars[i-1]}
+		case c == 'W' && isVowel(chars[i-1]):
+			replacement = []byte{chars[i-1]}
+		default:
+			replacement = []byte{c}
+		}
+
+		// Replacements rewrite the following characters too, so later steps see them
+		copy(chars[i:], replacement)
+		if key[len(key)-1] != chars[i] {
+			key = append(key, chars[i])
+		}
+	}
+
+	if len(key) > 1 && key[len(key)-1] == 'S' {
+		key = key[:len(key)-1]
+	}
+	if len(key) > 2 && key[len(key)-2] == 'A' && key[len(key)-1] == 'Y' {
+		key = append(key[:len(key)-2], 'Y')
+	}
+	if len(key) > 1 && key[len(key)-1] == 'A' {
+		key = key[:len(key)-1]
+	}
+	if len(key) > 6 {
+		key = key[:6]
+	}
+	return string(key), ""
+}
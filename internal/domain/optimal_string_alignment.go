@@ -0,0 +1,37 @@
+package domain
+
+// OptimalStringAlignment is the restricted Damerau-Levenshtein similarity: adjacent transpositions
+// count as a single edit, but no substring is edited more than once
+type OptimalStringAlignment struct{}
+
+// Compare returns the optimal string alignment similarity of a and b
+func (OptimalStringAlignment) Compare(a, b string) float64 {
+	r1, r2 := []rune(a), []rune(b)
+	return editSimilarity(optimalStringAlignmentDistance(r1, r2), len(r1), len(r2))
+}
+
+// optimalStringAlignmentDistance computes the optimal string alignment distance
+func optimalStringAlignmentDistance(r1, r2 []rune) int {
+	d := make([][]int, len(r1)+1)
+	for i := range d {
+		d[i] = make([]int, len(r2)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] && r1[i-2] == r2[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(r1)][len(r2)]
+}
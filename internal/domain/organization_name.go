@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LegalFormClass groups legal forms that describe the same kind of entity across jurisdictions
+type LegalFormClass string
+
+const (
+	// LegalFormCompany is a company whose owners' liability is limited, public or private (Inc, LLC,
+	// Ltd, PLC, GmbH, AG, S.A., Ltda, KK)
+	LegalFormCompany LegalFormClass = "company"
+	// LegalFormPartnership is a partnership (LP, LLP, KG)
+	LegalFormPartnership LegalFormClass = "partnership"
+	// LegalFormGeneric is a form that does not tell the kind of entity ("Co", "Company")
+	LegalFormGeneric LegalFormClass = "generic"
+)
+
+// legalForm is the canonical spelling and class of a legal form
+type legalForm struct {
+	canonical string
+	class     LegalFormClass
+}
+
+// legalForms maps the normalized spellings of legal forms, as space-separated tokens, to their
+// canonical form. Dotted abbreviations are joined by the normalizer, so "S.A. de C.V." is "sa de cv".
+var legalForms = map[string]legalForm{
+	"inc":                                   {"inc", LegalFormCompany},
+	"incorporated":                          {"inc", LegalFormCompany},
+	"corp":                                  {"corp", LegalFormCompany},
+	"corporation":                           {"corp", LegalFormCompany},
+	"plc":                                   {"plc", LegalFormCompany},
+	"public limited company":                {"plc", LegalFormCompany},
+	"ag":                                    {"ag", LegalFormCompany},
+	"aktiengesellschaft":                    {"ag", LegalFormCompany},
+	"sa":                                    {"sa", LegalFormCompany},
+	"societe anonyme":                       {"sa", LegalFormCompany},
+	"sociedad anonima":                      {"sa", LegalFormCompany},
+	"sa de cv":                              {"sa de cv", LegalFormCompany},
+	"sab de cv":                             {"sa de cv", LegalFormCompany},
+	"spa":                                   {"spa", LegalFormCompany},
+	"societa per azioni":                    {"spa", LegalFormCompany},
+	"nv":                                    {"nv", LegalFormCompany},
+	"naamloze vennootschap":                 {"nv", LegalFormCompany},
+	"kk":                                    {"kk", LegalFormCompany},
+	"kabushiki kaisha":                      {"kk", LegalFormCompany},
+	"ab":                                    {"ab", LegalFormCompany},
+	"aktiebolag":                            {"ab", LegalFormCompany},
+	"oyj":                                   {"oyj", LegalFormCompany},
+	"bhd":                                   {"bhd", LegalFormCompany},
+	"berhad":                                {"bhd", LegalFormCompany},
+	"ltd":                                   {"ltd", LegalFormCompany},
+	"limited":                               {"ltd", LegalFormCompany},
+	"co ltd":                                {"ltd", LegalFormCompany},
+	"pty ltd":                               {"pty ltd", LegalFormCompany},
+	"pvt ltd":                               {"pvt ltd", LegalFormCompany},
+	"private limited":                       {"pvt ltd", LegalFormCompany},
+	"llc":                                   {"llc", LegalFormCompany},
+	"limited liability co":                  {"llc", LegalFormCompany},
+	"limited liability company":             {"llc", LegalFormCompany},
+	"gmbh":                                  {"gmbh", LegalFormCompany},
+	"gesellschaft mit beschrankter haftung": {"gmbh", LegalFormCompany},
+	"ug":                                    {"ug", LegalFormCompany},
+	"ltda":                                  {"ltda", LegalFormCompany},
+	"limitada":                              {"ltda", LegalFormCompany},
+	"srl":                                   {"srl", LegalFormCompany},
+	"sarl":                                  {"sarl", LegalFormCompany},
+	"sas":                                   {"sas", LegalFormCompany},
+	"s de rl":                               {"s de rl", LegalFormCompany},
+	"s de rl de cv":                         {"s de rl", LegalFormCompany},
+	"bv":                                    {"bv", LegalFormCompany},
+	"besloten vennootschap":                 {"bv", LegalFormCompany},
+	"gk":                                    {"gk", LegalFormCompany},
+	"godo kaisha":                           {"gk", LegalFormCompany},
+	"sdn bhd":                               {"sdn bhd", LegalFormCompany},
+	"oy":                                    {"oy", LegalFormCompany},
+	"as":                                    {"as", LegalFormCompany},
+	"a s":                                   {"as", LegalFormCompany},
+	"aps":                                   {"aps", LegalFormCompany},
+	"lp":                                    {"lp", LegalFormPartnership},
+	"limited partnership":                   {"lp", LegalFormPartnership},
+	"llp":                                   {"llp", LegalFormPartnership},
+	"limited liability partnership":         {"llp", LegalFormPartnership},
+	"kg":                                    {"kg", LegalFormPartnership},
+	"kommanditgesellschaft":                 {"kg", LegalFormPartnership},
+	"ohg":                                   {"ohg", LegalFormPartnership},
+	"gbr":                                   {"gbr", LegalFormPartnership},
+	"snc":                                   {"snc", LegalFormPartnership},
+	"co":                                    {"co", LegalFormGeneric},
+	"company":                               {"co", LegalFormGeneric},
+	"cia":                                   {"co", LegalFormGeneric},
+	"cie":                                   {"co", LegalFormGeneric},
+}
+
+// maxLegalFormTokens is the number of tokens of the longest spelling in legalForms
+const maxLegalFormTokens = 5
+
+// organizationStopwords are the connecting words left out of organization names ("The Boeing
+// Company", "Johnson & Johnson"); the ampersand is normalized to "and"
+var organizationStopwords = map[string]bool{
+	"the": true, "and": true, "of": true, "for": true, "und": true, "et": true, "y": true,
+}
+
+// organizationAbbreviations maps the abbreviations common in organization names to the word they
+// stand for ("Intl" and "International")
+var organizationAbbreviations = map[string]string{
+	"intl":   "international",
+	"natl":   "national",
+	"assn":   "association",
+	"assoc":  "association",
+	"bros":   "brothers",
+	"mfg":    "manufacturing",
+	"svc":    "service",
+	"svcs":   "services",
+	"sys":    "systems",
+	"tech":   "technology",
+	"grp":    "group",
+	"hldg":   "holding",
+	"hldgs":  "holdings",
+	"mgmt":   "management",
+	"dept":   "department",
+	"univ":   "university",
+	"inst":   "institute",
+	"ctr":    "center",
+	"centre": "center",
+	"ind":    "industries",
+	"inds":   "industries",
+	"amer":   "american",
+	"elec":   "electric",
+	"engr":   "engineering",
+	"fin":    "financial",
+	"ins":    "insurance",
+	"invt":   "investment",
+	"labs":   "laboratories",
+	"lab":    "laboratory",
+	"mktg":   "marketing",
+	"prods":  "products",
+	"dist":   "distribution",
+}
+
+// OrganizationName holds the components of an organization name that take part in a comparison
+type OrganizationName struct {
+	// Tokens are the words of the name without its legal forms and stopwords, abbreviations expanded
+	Tokens []string `json:"tokens"`
+	// LegalForms are the canonical legal forms the name ends with, in order ("Corporation Ltd" is corp, ltd)
+	LegalForms []string `json:"legal_forms,omitempty"`
+}
+
+// NormalizeOrganizationName standardizes an organization name: lowercase, romanized, without
+// diacritics, with "&" spelled "and", apostrophes dropped ("Int'l"), dotted abbreviations joined
+// ("S.A." is "sa") and any other character but letters and digits replaced with spaces
+func NormalizeOrganizationName(name string) string {
+	name = RemoveDiacritics(Transliterate(strings.ToLower(name)))
+
+	var sb strings.Builder
+	run := 0 // letters and digits since the last separator or period
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+			run++
+		case r == '.' && run == 1:
+			// an abbreviation dot after a single letter joins it to the next one
+			run = 0
+		case r == '\'' || r == '’':
+		case r == '&':
+			sb.WriteString(" and ")
+			run = 0
+		default:
+			sb.WriteRune(' ')
+			run = 0
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// ParseOrganizationName splits an organization name into its comparable tokens and its legal forms.
+// Stopwords are dropped, then legal forms are stripped from the end of the name and canonicalized
+// ("Ltd." and "Limited" are ltd, "Sociedad Anónima" is sa), and abbreviations are expanded. The
+// name always keeps at least one token, so "The Limited" is not reduced to nothing.
+func ParseOrganizationName(name string) OrganizationName {
+	var parsed OrganizationName
+	tokens := strings.Fields(NormalizeOrganizationName(name))
+
+	var words []string
+	for _, token := range tokens {
+		if !organizationStopwords[token] {
+			words = append(words, token)
+		}
+	}
+	if len(words) == 0 {
+		words = tokens
+	}
+
+	for len(words) > 1 {
+		form, n := trailingLegalForm(words)
+		if n == 0 {
+			break
+		}
+		parsed.LegalForms = append([]string{form.canonical}, parsed.LegalForms...)
+		words = words[:len(words)-n]
+	}
+
+	for _, word := range words {
+		if expanded, ok := organizationAbbreviations[word]; ok {
+			word = expanded
+		}
+		parsed.Tokens = append(parsed.Tokens, word)
+	}
+	return parsed
+}
+
+// trailingLegalForm returns the longest legal form the tokens end with and its number of tokens,
+// leaving at least one token for the name; n is zero when the tokens do not end with a legal form
+func trailingLegalForm(tokens []string) (form legalForm, n int) {
+	for n = min(maxLegalFormTokens, len(tokens)-1); n > 0; n-- {
+		if form, ok := legalForms[strings.Join(tokens[len(tokens)-n:], " ")]; ok {
+			return form, n
+		}
+	}
+	return legalForm{}, 0
+}
+
+// legalFormsConflict reports whether two lists of canonical legal forms name different kinds of
+// entity ("LLC" and "LP"). Generic forms and names without a legal form never conflict.
+func legalFormsConflict(forms1, forms2 []string) bool {
+	classes1, classes2 := legalFormClasses(forms1), legalFormClasses(forms2)
+	if len(classes1) == 0 || len(classes2) == 0 {
+		return false
+	}
+	for class := range classes1 {
+		if classes2[class] {
+			return false
+		}
+	}
+	return true
+}
+
+// legalFormClasses returns the specific classes of canonical legal forms
+func legalFormClasses(forms []string) map[LegalFormClass]bool {
+	classes := map[LegalFormClass]bool{}
+	for _, canonical := range forms {
+		if form := legalForms[canonical]; form.class != LegalFormGeneric {
+			classes[form.class] = true
+		}
+	}
+	return classes
+}
+
+// acronymOf reports whether a single-token name is the acronym of a name with several tokens
+// ("IBM" and "International Business Machines")
+func acronymOf(acronym string, tokens []string) bool {
+	if len(tokens) < 2 || utf8.RuneCountInString(acronym) != len(tokens) {
+		return false
+	}
+	var initials strings.Builder
+	for _, token := range tokens {
+		r, _ := utf8.DecodeRuneInString(token)
+		initials.WriteRune(r)
+	}
+	return initials.String() == acronym
+}
@@ -0,0 +1,260 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ParsedName is a personal name split into its components. All components are normalized tokens.
+type ParsedName struct {
+	Prefix   string   `json:"prefix,omitempty"`
+	Given    string   `json:"given,omitempty"`
+	Middle   []string `json:"middle,omitempty"`
+	Surname  []string `json:"surname,omitempty"`
+	Suffix   string   `json:"suffix,omitempty"`
+	Nickname string   `json:"nickname,omitempty"`
+	// PaternalSurname and MaternalSurname split Surname when names are compared under the Spanish
+	// double-surname convention (see CompareOptions.DoubleSurnames)
+	PaternalSurname []string `json:"paternal_surname,omitempty"`
+	MaternalSurname []string `json:"maternal_surname,omitempty"`
+}
+
+// NameRole is the component of a name a token belongs to
+type NameRole string
+
+const (
+	RoleGiven   NameRole = "given"
+	RoleMiddle  NameRole = "middle"
+	RoleSurname NameRole = "surname"
+)
+
+// nameToken is a name token together with its component role
+type nameToken struct {
+	text string
+	role NameRole
+}
+
+// Tokens returns the given, middle and surname tokens in order
+func (p ParsedName) Tokens() []string {
+	tokens := make([]string, 0, 2+len(p.Middle)+len(p.Surname))
+	for _, token := range p.roleTokens() {
+		tokens = append(tokens, token.text)
+	}
+	return tokens
+}
+
+// SurnameString returns the surname tokens joined by spaces
+func (p ParsedName) SurnameString() string {
+	return strings.Join(p.Surname, " ")
+}
+
+// matchTokens returns the role tokens without the surname particles, which are optional when
+// names are compared
+func (p ParsedName) matchTokens() []nameToken {
+	tokens := p.roleTokens()
+	matched := tokens[:0:0]
+	for i, token := range tokens {
+		if token.role == RoleSurname && i < len(tokens)-1 && isOptionalSurnameToken(token.text) {
+			continue
+		}
+		matched = append(matched, token)
+	}
+	return matched
+}
+
+// roleTokens returns the given, middle and surname tokens in order, tagged with their role
+func (p ParsedName) roleTokens() []nameToken {
+	var tokens []nameToken
+	if p.Given != "" {
+		tokens = append(tokens, nameToken{p.Given, RoleGiven})
+	}
+	for _, middle := range p.Middle {
+		tokens = append(tokens, nameToken{middle, RoleMiddle})
+	}
+	for _, surname := range p.Surname {
+		tokens = append(tokens, nameToken{surname, RoleSurname})
+	}
+	return tokens
+}
+
+// nicknamePattern matches nicknames written in double quotes, typographic quotes, parentheses,
+// or single quotes standing apart from the surrounding words (so "O'Conner" is left alone)
+var nicknamePattern = regexp.MustCompile(`"([^"]+)"|“([^”]+)”|\(([^)]+)\)|(?:^|\s)'([^']+)'(?:\s|$)`)
+
+// nameTitles are honorifics and titles that may precede a name, in normalized form
+var nameTitles = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "miss": true, "mx": true, "dr": true, "prof": true,
+	"rev": true, "fr": true, "sir": true, "dame": true, "lord": true, "lady": true,
+	"sr": true, "sra": true, "srta": true, "dra": true, "lic": true, "ing": true,
+	"don": true, "dona": true, "herr": true, "frau": true, "mme": true, "mlle": true,
+}
+
+// ambiguousTitles double as given names ("Don Smith"), so they only count as titles when
+// a given name and a surname follow them
+var ambiguousTitles = map[string]bool{"don": true, "dona": true, "lord": true, "lady": true}
+
+// nameSuffixes maps generational and professional suffixes to their canonical form. Portuguese
+// "Filho", "Neto" and "Sobrinho" are left out: they are as often the surname itself ("Pedro Neto").
+var nameSuffixes = map[string]string{
+	"jr": "jr", "jnr": "jr", "junior": "jr",
+	"sr": "sr", "snr": "sr", "senior": "sr",
+	"ii": "ii", "iii": "iii", "iv": "iv",
+	"phd": "phd", "md": "md", "esq": "esq",
+}
+
+// generationalSuffixes are the canonical suffixes that tell generations of a family apart, unlike
+// credentials ("PhD") that the same person may or may not write
+var generationalSuffixes = map[string]bool{"jr": true, "sr": true, "ii": true, "iii": true, "iv": true}
+
+// ParseName parses a name into its components using the default matcher
+func ParseName(name string) ParsedName {
+	return defaultNameMatcher.Parse(name)
+}
+
+// Parse splits a name into prefix, given name, middle names, surname, suffix and nickname.
+// It recognizes titles ("Dr.", "Sra."), generational suffixes ("Jr.", "III"), quoted nicknames,
+// the "Last, First Middle" order and compound surnames opened by particles ("da Silva",
+// "van der Berg", "bin Rashid"); a Mac or Mc written apart is joined to the rest of the surname.
+// Names written in a CJK script put the surname first ("毛泽东" is surname Mao, given name
+// Zedong). Without other hints the first token is the given name and the last token the surname.
+func (m *NameMatcher) Parse(name string) ParsedName {
+	return m.ParseInOrder(name, OrderAuto)
+}
+
+// ParseInOrder parses a name like Parse, reading it in the given order unless the name spells
+// out its order with a comma ("Nagy, János")
+func (m *NameMatcher) ParseInOrder(name string, order NameOrder) ParsedName {
+	parsed, _ := m.parseInOrder(name, order)
+	return parsed
+}
+
+// parseInOrder parses a name and returns the order it was read in
+func (m *NameMatcher) parseInOrder(name string, order NameOrder) (ParsedName, NameOrder) {
+	var parsed ParsedName
+	if order == OrderAuto {
+		order = OrderGivenFirst
+		if isCJKScript(NameScript(name)) {
+			order = OrderFamilyFirst
+		}
+	}
+
+	// Pull out the nickname before anything else, so its quotes do not leak into the tokens
+	if match := m.nicknamePattern.FindStringSubmatch(name); match != nil {
+		for _, group := range match[1:] {
+			if group != "" {
+				parsed.Nickname = strings.Join(m.Tokenize(group), " ")
+				break
+			}
+		}
+		name = m.nicknamePattern.ReplaceAllString(name, " ")
+	}
+
+	// "Last, First Middle" order, unless everything after the comma is a suffix ("John Doe, Jr.")
+	var surnameFirst []string
+	if before, after, found := strings.Cut(name, ","); found {
+		afterTokens := m.Tokenize(after)
+		if len(afterTokens) > 0 && !allSuffixes(afterTokens) {
+			surnameFirst = joinSurnamePrefixes(m.Tokenize(before), 0)
+			name = after
+		} else {
+			name = before + " " + after
+		}
+	}
+
+	firstSurnameToken := 1
+	if order == OrderFamilyFirst {
+		firstSurnameToken = 0
+	}
+	tokens := joinSurnamePrefixes(m.Tokenize(name), firstSurnameToken)
+	tokens, parsed.Prefix = splitTitles(tokens, len(surnameFirst) > 0)
+	tokens, parsed.Suffix = splitSuffixes(tokens, len(surnameFirst) > 0)
+
+	switch {
+	case len(surnameFirst) > 0:
+		order = OrderFamilyFirst
+		parsed.Surname = surnameFirst
+		if len(tokens) > 0 {
+			parsed.Given = tokens[0]
+			parsed.Middle = tokens[1:]
+		}
+	case len(tokens) > 1 && order == OrderFamilyFirst:
+		parsed.Surname = tokens[:1]
+		parsed.Given = tokens[1]
+		parsed.Middle = tokens[2:]
+	case len(tokens) == 1 && parsed.Prefix != "":
+		// "Dr. Smith": a title followed by a single name addresses the surname
+		parsed.Surname = tokens
+	case len(tokens) == 1:
+		parsed.Given = tokens[0]
+	case len(tokens) > 1:
+		// The surname is the last token with the particles before it ("Juan de la Cruz")
+		start := surnameStart(tokens)
+		parsed.Surname = tokens[start:]
+		if start > 0 {
+			parsed.Given = tokens[0]
+			parsed.Middle = tokens[1:start]
+		}
+	}
+	if len(parsed.Middle) == 0 {
+		parsed.Middle = nil
+	}
+	return parsed, order
+}
+
+// splitTitles removes leading titles from tokens and returns them joined as the prefix.
+// A title is only removed when a name still follows it; hasSurname reports whether the
+// surname was already taken from a "Last, First" comma order.
+func splitTitles(tokens []string, hasSurname bool) ([]string, string) {
+	var titles []string
+	for len(tokens) > 1 || (hasSurname && len(tokens) > 0) {
+		title := tokens[0]
+		if !nameTitles[title] || (ambiguousTitles[title] && len(tokens) < 3) {
+			break
+		}
+		titles = append(titles, title)
+		tokens = tokens[1:]
+	}
+	return tokens, strings.Join(titles, " ")
+}
+
+// splitSuffixes removes trailing generational and professional suffixes from tokens and
+// returns them, in canonical form, joined as the suffix
+func splitSuffixes(tokens []string, hasSurname bool) ([]string, string) {
+	var suffixes []string
+	for len(tokens) > 1 || (hasSurname && len(tokens) > 0) {
+		suffix, ok := nameSuffixes[tokens[len(tokens)-1]]
+		if !ok {
+			break
+		}
+		suffixes = append([]string{suffix}, suffixes...)
+		tokens = tokens[:len(tokens)-1]
+	}
+	return tokens, strings.Join(suffixes, " ")
+}
+
+// suffixesConflict reports whether two joined suffixes name different generations ("jr" and
+// "sr phd"). Credentials never conflict, and neither does a suffix missing from one name.
+func suffixesConflict(suffix1, suffix2 string) bool {
+	generations := func(suffix string) []string {
+		var generational []string
+		for _, s := range strings.Fields(suffix) {
+			if generationalSuffixes[s] {
+				generational = append(generational, s)
+			}
+		}
+		return generational
+	}
+	generations1, generations2 := generations(suffix1), generations(suffix2)
+	return len(generations1) > 0 && len(generations2) > 0 && !slices.Equal(generations1, generations2)
+}
+
+// allSuffixes reports whether every token is a known name suffix
+func allSuffixes(tokens []string) bool {
+	for _, token := range tokens {
+		if _, ok := nameSuffixes[token]; !ok {
+			return false
+		}
+	}
+	return true
+}
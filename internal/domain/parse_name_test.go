@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name string
+		want ParsedName
+	}{
+		{"Brayan Ferney Perez", ParsedName{Given: "brayan", Middle: []string{"ferney"}, Surname: []string{"perez"}}},
+		{"Perez, Brayan", ParsedName{Given: "brayan", Surname: []string{"perez"}}},
+		{"Perez Moreno, Brayan Ferney", ParsedName{Given: "brayan", Middle: []string{"ferney"}, Surname: []string{"perez", "moreno"}}},
+		{"Dr. John Doe Jr.", ParsedName{Prefix: "dr", Given: "john", Surname: []string{"doe"}, Suffix: "jr"}},
+		{"John Doe, Jr.", ParsedName{Given: "john", Surname: []string{"doe"}, Suffix: "jr"}},
+		{"Sr. Juan Lopez", ParsedName{Prefix: "sr", Given: "juan", Surname: []string{"lopez"}}},
+		{"Juan Lopez Sr.", ParsedName{Given: "juan", Surname: []string{"lopez"}, Suffix: "sr"}},
+		{"William \"Bill\" Gates III", ParsedName{Given: "william", Surname: []string{"gates"}, Suffix: "iii", Nickname: "bill"}},
+		{"Robert (Bob) Smith", ParsedName{Given: "robert", Surname: []string{"smith"}, Nickname: "bob"}},
+		{"Don Smith", ParsedName{Given: "don", Surname: []string{"smith"}}},
+		{"Don Diego Vega", ParsedName{Prefix: "don", Given: "diego", Surname: []string{"vega"}}},
+		{"Dr. Smith", ParsedName{Prefix: "dr", Surname: []string{"smith"}}},
+		{"Patrick O'Conner", ParsedName{Given: "patrick", Surname: []string{"oconner"}}},
+		{"Madonna", ParsedName{Given: "madonna"}},
+		{"Juan Carlos de la Cruz", ParsedName{Given: "juan", Middle: []string{"carlos"}, Surname: []string{"de", "la", "cruz"}}},
+		{"Ludwig van Beethoven", ParsedName{Given: "ludwig", Surname: []string{"van", "beethoven"}}},
+		{"da Silva", ParsedName{Surname: []string{"da", "silva"}}},
+		{"Van Morrison", ParsedName{Given: "van", Surname: []string{"morrison"}}},
+		{"Ronald Mac Donald", ParsedName{Given: "ronald", Surname: []string{"macdonald"}}},
+		{"Mac Donald, Ronald", ParsedName{Given: "ronald", Surname: []string{"macdonald"}}},
+	}
+	for _, tt := range tests {
+		if got := ParseName(tt.name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseName('%s') = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompareNamesUsesParsedComponents(t *testing.T) {
+	pairs := [][2]string{
+		{"Perez, Brayan", "Brayan Perez"},
+		{"Dr. John Doe", "John Doe"},
+		{"William \"Bill\" Gates", "Bill Gates"},
+	}
+	for _, pair := range pairs {
+		if score := CompareNames(pair[0], pair[1]); score != 1.0 {
+			t.Errorf("'%s' vs '%s': expected 1.00, got %.2f", pair[0], pair[1], score)
+		}
+	}
+}
+
+func TestCompareNamesSuffixMismatch(t *testing.T) {
+	result := CompareNamesDetailed("John Doe Jr.", "John Doe Sr.")
+
+	if !result.SuffixConflict {
+		t.Errorf("Expected a suffix conflict, got %+v", result)
+	}
+	want := 1.0 - DefaultScoringConfig().SuffixMismatchPenalty
+	if result.Score != want {
+		t.Errorf("Expected score %.2f after the suffix penalty, got %.2f", want, result.Score)
+	}
+	if score := CompareNames("John Doe Jr.", "John Doe"); score != 1.0 {
+		t.Errorf("Expected a missing suffix not to be penalized, got %.2f", score)
+	}
+}
+
+func TestCompareNamesIgnoresCredentialsInSuffixes(t *testing.T) {
+	for _, pair := range [][2]string{{"John Doe Jr. PhD", "John Doe Jr."}, {"John Doe PhD", "John Doe Jr."}} {
+		if result := CompareNamesDetailed(pair[0], pair[1]); result.SuffixConflict || result.Score != 1.0 {
+			t.Errorf("'%s' vs '%s': expected credentials not to conflict, got %.2f", pair[0], pair[1], result.Score)
+		}
+	}
+	if !CompareNamesDetailed("John Doe Jr. PhD", "John Doe Sr.").SuffixConflict {
+		t.Errorf("Expected 'Jr.' and 'Sr.' to conflict whatever the credentials")
+	}
+}
+
+func TestParseNameKeepsPortugueseSurnames(t *testing.T) {
+	if parsed := ParseName("Pedro Neto"); parsed.Suffix != "" || !slices.Equal(parsed.Surname, []string{"neto"}) {
+		t.Errorf("Expected 'Neto' to be the surname, got %+v", parsed)
+	}
+	if score := CompareNames("Pedro Neto", "Pedro Silva"); score >= 0.8 {
+		t.Errorf("Expected 'Pedro Neto' and 'Pedro Silva' to be different people, got %.2f", score)
+	}
+}
@@ -3,13 +3,99 @@ package domain
 import (
 	"fmt"
 	"github.com/dlclark/metaphone3"
+	"sync"
 )
 
-// PhoneticMatch generates the Double Metaphone encoding for a name
+// PhoneticEncoder encodes a name into phonetic keys. Encoders that only produce a single key
+// return an empty alternate key. Encoders expect normalized input (see NormalizeName).
+type PhoneticEncoder interface {
+	Encode(name string) (primary, alternate string)
+}
+
+// Names of the built-in phonetic encoders, as used in ScoringConfig.PhoneticEncoders
+const (
+	PhoneticMetaphone3      = "metaphone3"
+	PhoneticDoubleMetaphone = "double-metaphone"
+	PhoneticSoundex         = "soundex"
+	PhoneticRefinedSoundex  = "refined-soundex"
+	PhoneticNYSIIS          = "nysiis"
+	PhoneticCaverphone2     = "caverphone2"
+	PhoneticCologne         = "cologne"
+	PhoneticBeiderMorse     = "beider-morse"
+)
+
+// phoneticEncoders maps encoder names to their implementations
+var phoneticEncoders = map[string]PhoneticEncoder{
+	PhoneticMetaphone3:      NewMetaphone3(),
+	PhoneticDoubleMetaphone: DoubleMetaphone{},
+	PhoneticSoundex:         Soundex{},
+	PhoneticRefinedSoundex:  RefinedSoundex{},
+	PhoneticNYSIIS:          NYSIIS{},
+	PhoneticCaverphone2:     Caverphone2{},
+	PhoneticCologne:         ColognePhonetic{},
+	PhoneticBeiderMorse:     BeiderMorse{},
+}
+
+// PhoneticEncoderByName returns the built-in phonetic encoder registered under name
+func PhoneticEncoderByName(name string) (PhoneticEncoder, error) {
+	encoder, ok := phoneticEncoders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown phonetic encoder %q", name)
+	}
+	return encoder, nil
+}
+
+// Metaphone3 is the PhoneticEncoder backed by the Metaphone3 algorithm. metaphone3.Encoder keeps
+// its buffers between calls and is not safe for concurrent use, so encoders are pooled; the zero
+// value allocates a new encoder for every call.
+type Metaphone3 struct {
+	encoders *sync.Pool
+}
+
+// NewMetaphone3 creates a Metaphone3 encoder that reuses metaphone3.Encoder instances
+func NewMetaphone3() Metaphone3 {
+	return Metaphone3{encoders: &sync.Pool{New: func() any { return &metaphone3.Encoder{} }}}
+}
+
+// Encode returns the Metaphone3 primary and alternate keys of name
+func (m Metaphone3) Encode(name string) (string, string) {
+	if m.encoders == nil {
+		mp := metaphone3.Encoder{}
+		return mp.Encode(name)
+	}
+	mp := m.encoders.Get().(*metaphone3.Encoder)
+	defer m.encoders.Put(mp)
+	return mp.Encode(name)
+}
+
+// PhoneticMatch generates the Metaphone3 encoding for a name
 func PhoneticMatch(name string) (string, string) {
 	normalized := NormalizeName(name)
-	mp := metaphone3.Encoder{}
-	primaryKey, alternateKey := mp.Encode(normalized)
-	fmt.Printf("Phonetic match for '%s' -> Primary: %s, Alternate: %s\n", name, primaryKey, alternateKey)
-	return primaryKey, alternateKey
+	return phoneticEncoders[PhoneticMetaphone3].Encode(normalized)
+}
+
+// phoneticCodesMatch reports whether any non-empty key of code1 equals any non-empty key of code2
+func phoneticCodesMatch(code1, code2 PhoneticCode) bool {
+	for _, key1 := range []string{code1.Primary, code1.Alternate} {
+		for _, key2 := range []string{code2.Primary, code2.Alternate} {
+			if key1 != "" && key1 == key2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// asciiLetters keeps only the ASCII letters of s, in upper case
+func asciiLetters(s string) []byte {
+	letters := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			letters = append(letters, byte(r-'a'+'A'))
+		case r >= 'A' && r <= 'Z':
+			letters = append(letters, byte(r))
+		}
+	}
+	return letters
 }
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+)
+
+func assertPhoneticKeys(t *testing.T, encoder PhoneticEncoder, name, primary, alternate string) {
+	t.Helper()
+	gotPrimary, gotAlternate := encoder.Encode(name)
+	if gotPrimary != primary || gotAlternate != alternate {
+		t.Errorf("%T.Encode('%s') = (%s, %s), expected (%s, %s)", encoder, name, gotPrimary, gotAlternate, primary, alternate)
+	}
+}
+
+func TestSoundexEncoders(t *testing.T) {
+	assertPhoneticKeys(t, Soundex{}, "Robert", "R163", "")
+	assertPhoneticKeys(t, Soundex{}, "Rupert", "R163", "")
+	assertPhoneticKeys(t, Soundex{}, "Ashcraft", "A261", "")
+	assertPhoneticKeys(t, Soundex{}, "Tymczak", "T522", "")
+	assertPhoneticKeys(t, Soundex{}, "Pfister", "P236", "")
+	assertPhoneticKeys(t, Soundex{}, "", "", "")
+
+	assertPhoneticKeys(t, RefinedSoundex{}, "testing", "T6036084", "")
+	assertPhoneticKeys(t, RefinedSoundex{}, "jumped", "J408106", "")
+	assertPhoneticKeys(t, RefinedSoundex{}, "lazy", "L7050", "")
+}
+
+func TestNYSIISEncoder(t *testing.T) {
+	assertPhoneticKeys(t, NYSIIS{}, "Andrew", "ANDR", "")
+	assertPhoneticKeys(t, NYSIIS{}, "Nolan", "NALAN", "")
+	assertPhoneticKeys(t, NYSIIS{}, "Case", "CAS", "")
+	assertPhoneticKeys(t, NYSIIS{}, "Robertson", "RABART", "")
+}
+
+func TestDoubleMetaphoneEncoder(t *testing.T) {
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Smith", "SM0", "XMT")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Schmidt", "XMT", "SMT")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Michael", "MKL", "MXL")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Jose", "HS", "")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Thomas", "TMS", "")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Cabrillo", "KPRL", "KPR")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Arnow", "ARN", "ARNF")
+	assertPhoneticKeys(t, DoubleMetaphone{}, "Xavier", "SF", "SFR")
+}
+
+func TestCaverphone2Encoder(t *testing.T) {
+	assertPhoneticKeys(t, Caverphone2{}, "Lee", "LA11111111", "")
+	assertPhoneticKeys(t, Caverphone2{}, "Peter", "PTA1111111", "")
+	assertPhoneticKeys(t, Caverphone2{}, "Stevenson", "STFNSN1111", "")
+}
+
+func TestColognePhoneticEncoder(t *testing.T) {
+	assertPhoneticKeys(t, ColognePhonetic{}, "Müller-Lüdenscheidt", "65752682", "")
+	assertPhoneticKeys(t, ColognePhonetic{}, "Wikipedia", "3412", "")
+	assertPhoneticKeys(t, ColognePhonetic{}, "Breschnew", "17863", "")
+	assertPhoneticKeys(t, ColognePhonetic{}, "muller", "657", "")
+	assertPhoneticKeys(t, ColognePhonetic{}, "mueller", "657", "")
+}
+
+func TestBeiderMorseEncoder(t *testing.T) {
+	pairs := [][2]string{
+		{"jimenez", "ximenez"},
+		{"gimenez", "jimenez"},
+		{"muller", "mueller"},
+		{"wagner", "vagner"},
+		{"schmidt", "smith"},
+		{"meyer", "maier"},
+	}
+	for _, pair := range pairs {
+		primary1, alternate1 := BeiderMorse{}.Encode(pair[0])
+		primary2, alternate2 := BeiderMorse{}.Encode(pair[1])
+		if !phoneticCodesMatch(PhoneticCode{primary1, alternate1}, PhoneticCode{primary2, alternate2}) {
+			t.Errorf("Expected '%s' (%s, %s) and '%s' (%s, %s) to share a Beider-Morse key", pair[0], primary1, alternate1, pair[1], primary2, alternate2)
+		}
+	}
+}
+
+func TestPhoneticCodesIgnoreEmptyKeys(t *testing.T) {
+	if phoneticCodesMatch(PhoneticCode{Primary: "A"}, PhoneticCode{Primary: "P"}) {
+		t.Errorf("Expected empty alternate keys not to count as a phonetic match")
+	}
+}
+
+func TestCompareNamesWithSeveralPhoneticEncoders(t *testing.T) {
+	config := DefaultScoringConfig()
+	score := NewNameMatcher(config).Compare("Jiménez", "Ximenez").Score
+	assertNoMatchWithLogging(t, score, 0.9, "Metaphone3 only for 'Jiménez' and 'Ximenez'")
+
+	config.PhoneticEncoders = []string{PhoneticMetaphone3, PhoneticBeiderMorse, PhoneticCologne}
+	result := NewNameMatcher(config).Compare("Jiménez", "Ximenez")
+	assertMatchWithLogging(t, result.Score, 0.9, "Metaphone3, Beider-Morse and Cologne for 'Jiménez' and 'Ximenez'")
+	if len(result.FirstName.Phonetic) != 3 {
+		t.Errorf("Expected keys from 3 phonetic encoders, got %+v", result.FirstName.Phonetic)
+	}
+}
@@ -0,0 +1,259 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// pinyinSyllables is the set of valid toneless Hanyu Pinyin syllables, with ü written as v
+var pinyinSyllables = func() map[string]bool {
+	syllables := map[string]bool{}
+	for _, syllable := range strings.Fields(`
+		a ai an ang ao ba bai ban bang bao bei ben beng bi bian biao bie bin bing bo bu
+		ca cai can cang cao ce cen ceng cha chai chan chang chao che chen cheng chi chong chou chu chua
+		chuai chuan chuang chui chun chuo ci cong cou cu cuan cui cun cuo da dai dan dang dao de dei den
+		deng di dia dian diao die ding diu dong dou du duan dui dun duo e ei en eng er fa fan fang fei
+		fen feng fo fou fu ga gai gan gang gao ge gei gen geng gong gou gu gua guai guan guang gui gun
+		guo ha hai han hang hao he hei hen heng hong hou hu hua huai huan huang hui hun huo ji jia jian
+		jiang jiao jie jin jing jiong jiu ju juan jue jun ka kai kan kang kao ke kei ken keng kong kou
+		ku kua kuai kuan kuang kui kun kuo la lai lan lang lao le lei leng li lia lian liang liao lie
+		lin ling liu lo long lou lu luan lue lun luo lv lve ma mai man mang mao me mei men meng mi mian
+		miao mie min ming miu mo mou mu na nai nan nang nao ne nei nen neng ni nian niang niao nie nin
+		ning niu nong nou nu nuan nue nuo nv nve o ou pa pai pan pang pao pei pen peng pi pian piao pie
+		pin ping po pou pu qi qia qian qiang qiao qie qin qing qiong qiu qu quan que qun ran rang rao
+		re ren reng ri rong rou ru rua ruan rui run ruo sa sai san sang sao se sen seng sha shai shan
+		shang shao she shei shen sheng shi shou shu shua shuai shuan shuang shui shun shuo si song sou
+		su suan sui sun suo ta tai tan tang tao te teng ti tian tiao tie ting tong tou tu tuan tui tun
+		tuo wa wai wan wang wei wen weng wo wu xi xia xian xiang xiao xie xin xing xiong xiu xu xuan
+		xue xun ya yan yang yao ye yi yin ying yo yong you yu yuan yue yun za zai zan zang zao ze zei
+		zen zeng zha zhai zhan zhang zhao zhe zhei zhen zheng zhi zhong zhou zhu zhua zhuai zhuan
+		zhuang zhui zhun zhuo zi zong zou zu zuan zui zun zuo`) {
+		syllables[syllable] = true
+	}
+	return syllables
+}()
+
+// wadeGilesSpecial are the Wade-Giles syllables that do not follow the initial and final rules
+var wadeGilesSpecial = map[string][]string{
+	"shih": {"shi"}, "chih": {"zhi", "chi"}, "jih": {"ri"}, "tzu": {"zi", "ci"}, "tsu": {"zi", "ci"},
+	"ssu": {"si"}, "szu": {"si"}, "erh": {"er"}, "yu": {"you", "yu"}, "yung": {"yong"},
+}
+
+// wadeGilesInitials maps Wade-Giles initials to their pinyin. Aspiration apostrophes are removed
+// by normalization, so each initial stands for both its plain and aspirated pinyin.
+var wadeGilesInitials = []struct {
+	initial string
+	pinyin  []string
+}{
+	{"hs", []string{"x"}}, {"ch", []string{"zh", "ch", "j", "q"}}, {"ts", []string{"z", "c"}},
+	{"tz", []string{"z", "c"}}, {"sh", []string{"sh"}}, {"k", []string{"g", "k"}},
+	{"p", []string{"b", "p"}}, {"t", []string{"d", "t"}}, {"j", []string{"r"}},
+}
+
+// wadeGilesFinals maps Wade-Giles finals to their pinyin
+var wadeGilesFinals = map[string]string{
+	"ieh": "ie", "ien": "ian", "iung": "iong", "ung": "ong", "ueh": "ue", "ih": "i", "uei": "ui",
+}
+
+// romanizedSurnameVariants groups the common spellings of Korean surnames (Revised Romanization
+// first) and the Cantonese, Hokkien and Wade-Giles spellings of Chinese surnames (pinyin first)
+var romanizedSurnameVariants = NewNicknameDictionary([][]string{
+	// Korean
+	{"gim", "kim"}, {"i", "lee", "yi", "rhee", "rhie", "ri"}, {"bak", "park", "pak", "bahk"},
+	{"choe", "choi", "choy"}, {"jeong", "jung", "chung", "jeung"}, {"gang", "kang"}, {"jo", "cho", "joe"},
+	{"yun", "yoon", "youn"}, {"jang", "chang"}, {"im", "lim", "yim", "rim"}, {"han", "hahn"}, {"o", "oh"},
+	{"seo", "suh"}, {"sin", "shin"}, {"gwon", "kwon"}, {"hwang", "whang"}, {"an", "ahn"},
+	{"yu", "yoo", "you"}, {"ryu", "ryoo", "yoo"}, {"go", "ko", "koh"}, {"mun", "moon"}, {"son", "sohn"},
+	{"bae", "pae"}, {"baek", "paek", "baik", "paik"}, {"heo", "hur", "huh", "her"}, {"sim", "shim"},
+	{"no", "roh", "noh", "rho"}, {"gwak", "kwak"}, {"seong", "sung"}, {"ju", "joo", "chu"}, {"u", "woo"},
+	{"gu", "koo", "ku"}, {"na", "ra", "nah"}, {"eom", "um", "uhm"}, {"cheon", "chun"}, {"gong", "kong"},
+	{"hyeon", "hyun"}, {"jeon", "jun", "chun", "chon"}, {"geum", "keum"}, {"namgung", "namkung"},
+	// Chinese
+	{"zhang", "chang", "cheung", "teo", "chong"}, {"chen", "chan", "tan", "chin"}, {"huang", "wong", "hwang", "oei"},
+	{"wang", "wong", "ong"}, {"li", "lee", "lei"}, {"liu", "lau", "lao", "low"}, {"yang", "yeung", "yeo", "yong"},
+	{"zhao", "chiu", "chao", "chew"}, {"wu", "ng", "woo", "goh"}, {"zhou", "chow", "chou", "chew"},
+	{"xu", "hsu", "hui", "tsui", "koh"}, {"sun", "suen", "soon"}, {"ma", "mah"}, {"zhu", "chu", "chue"},
+	{"hu", "wu", "foo"}, {"guo", "kuo", "kwok", "quek"}, {"he", "ho", "hoh"}, {"lin", "lam", "lim"},
+	{"luo", "lo", "law", "loh"}, {"zheng", "cheng", "cheang", "tay"}, {"liang", "leung", "neo"},
+	{"xie", "tse", "hsieh", "chia"}, {"song", "sung"}, {"tang", "tong"}, {"han", "hon"}, {"feng", "fung", "fong"},
+	{"cao", "tso", "tsao"}, {"peng", "pang"}, {"zeng", "tsang", "tseng"}, {"xiao", "siu", "hsiao", "seow"},
+	{"tian", "tin"}, {"dong", "tung"}, {"pan", "poon", "phua"}, {"yuan", "yuen"}, {"cai", "choi", "tsai", "chua"},
+	{"jiang", "chiang", "keung"}, {"ye", "yip", "yeh"}, {"du", "to", "tu"}, {"su", "so", "soh"},
+	{"lu", "lo", "loo", "lou", "lv", "lyu", "lui"}, {"wei", "ngai"}, {"ding", "ting"}, {"ren", "yam", "jen"},
+	{"shen", "shum", "sim"}, {"yao", "yiu"}, {"jin", "kam", "kim"}, {"qiu", "yau", "khoo", "chiu"},
+	{"mo", "mok"}, {"fang", "fong"}, {"shi", "shek", "sze"}, {"tan", "tam"}, {"mai", "mak"},
+	{"yu", "yue", "yee"}, {"xiang", "heung"}, {"qian", "chin"}, {"zhong", "chung"},
+	{"ouyang", "auyeung", "owyang"}, {"situ", "szeto"},
+})
+
+// koreanSpellingReplacements rewrite the McCune-Reischauer and informal spellings common in
+// Korean given names into the Revised Romanization
+var koreanSpellingReplacements = strings.NewReplacer(
+	"young", "yeong", "kyung", "gyeong", "sung", "seong", "soo", "su", "hee", "hui",
+)
+
+// romanizationsOf returns the canonical spellings a romanized CJK word may stand for: the word
+// itself, its pinyin when it is Wade-Giles, its passport-style Hepburn, its Revised Romanization
+// and the common spellings of the surname it may be. Two words written in different romanizations
+// of the same name share at least one canonical spelling.
+func romanizationsOf(word string) []string {
+	spellings := map[string]bool{word: true}
+	for _, spelling := range segmentRomanizedWord(word) {
+		spellings[spelling] = true
+	}
+	spellings[hepburnSpelling(word)] = true
+	spellings[koreanSpellingReplacements.Replace(word)] = true
+	for spelling := range spellings {
+		for _, variant := range romanizedSurnameVariants.Variants(spelling) {
+			spellings[variant] = true
+		}
+	}
+
+	result := make([]string, 0, len(spellings))
+	for spelling := range spellings {
+		result = append(result, spelling)
+	}
+	return result
+}
+
+// romanizationEquivalent reports whether two words are romanizations of the same CJK name
+func romanizationEquivalent(word1, word2 string) bool {
+	if word1 == word2 {
+		return false
+	}
+	return sharesSpelling(romanizationsOf(word1), romanizationsOf(word2))
+}
+
+// sameRomanizedName reports whether two normalized names spell the same CJK name, ignoring how
+// the romanization splits it into words ("Mao Tse-tung" and "Mao Zedong", "Kim Min Jun" and
+// "Gim Minjun")
+func sameRomanizedName(normalized1, normalized2 string) bool {
+	return sharesSpelling(romanizedNameSpellings(normalized1), romanizedNameSpellings(normalized2))
+}
+
+// maxRomanizedSpellings bounds the combinations of word spellings considered for one name
+const maxRomanizedSpellings = 256
+
+// romanizedNameSpellings returns the canonical spellings of a whole name, without separators
+func romanizedNameSpellings(normalized string) []string {
+	spellings := []string{""}
+	for _, word := range strings.Fields(strings.ReplaceAll(normalized, "-", "")) {
+		var next []string
+		for _, prefix := range spellings {
+			for _, spelling := range romanizationsOf(word) {
+				if len(next) < maxRomanizedSpellings {
+					next = append(next, prefix+spelling)
+				}
+			}
+		}
+		spellings = next
+	}
+	return spellings
+}
+
+// sharesSpelling reports whether two sets of spellings have a spelling in common
+func sharesSpelling(spellings1, spellings2 []string) bool {
+	seen := make(map[string]bool, len(spellings1))
+	for _, spelling := range spellings1 {
+		seen[spelling] = true
+	}
+	for _, spelling := range spellings2 {
+		if seen[spelling] {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentRomanizedWord splits a word into pinyin or Wade-Giles syllables ("zedong", "tsetung")
+// and returns the pinyin spellings it may stand for, or nothing when it is not made of syllables
+func segmentRomanizedWord(word string) []string {
+	const maxSyllable = 6
+	prefixes := make([][]string, len(word)+1)
+	prefixes[0] = []string{""}
+	for end := 1; end <= len(word); end++ {
+		for start := max(0, end-maxSyllable); start < end; start++ {
+			if len(prefixes[start]) == 0 {
+				continue
+			}
+			for _, syllable := range pinyinCandidates(word[start:end]) {
+				for _, prefix := range prefixes[start] {
+					if len(prefixes[end]) < maxRomanizedSpellings {
+						prefixes[end] = append(prefixes[end], prefix+syllable)
+					}
+				}
+			}
+		}
+	}
+	return prefixes[len(word)]
+}
+
+// pinyinCandidates returns the pinyin syllables a romanized syllable may stand for. Valid pinyin
+// is kept as is; only its ch initial is also read as Wade-Giles ("chang" may be "zhang"), since
+// the other Wade-Giles initials would confuse distinct pinyin surnames such as Tang and Dang.
+func pinyinCandidates(syllable string) []string {
+	if pinyinSyllables[syllable] {
+		if strings.HasPrefix(syllable, "ch") {
+			return append([]string{syllable}, wadeGilesToPinyin(syllable)...)
+		}
+		return []string{syllable}
+	}
+	return wadeGilesToPinyin(syllable)
+}
+
+// wadeGilesToPinyin returns the valid pinyin syllables a Wade-Giles syllable may stand for
+func wadeGilesToPinyin(syllable string) []string {
+	if pinyin, ok := wadeGilesSpecial[syllable]; ok {
+		return pinyin
+	}
+
+	initials, final := []string{""}, syllable
+	for _, rule := range wadeGilesInitials {
+		if strings.HasPrefix(syllable, rule.initial) {
+			initials, final = rule.pinyin, strings.TrimPrefix(syllable, rule.initial)
+			break
+		}
+	}
+	if pinyin, ok := wadeGilesFinals[final]; ok {
+		final = pinyin
+	}
+
+	var candidates []string
+	for _, initial := range initials {
+		finals := []string{final}
+		if final == "o" && initial != "" {
+			// Wade-Giles o is pinyin e after g, k and h, and uo after the other initials
+			finals = []string{"e", "uo"}
+		}
+		for _, f := range finals {
+			if candidate := initial + f; pinyinSyllables[candidate] && candidate != syllable {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+// kunreiFu matches the Kunrei-shiki hu, which Hepburn writes fu, but not the hu of shu and chu
+var kunreiFu = regexp.MustCompile(`(^|[^cs])hu`)
+
+// hepburnSpelling rewrites a romanized Japanese word into passport-style Hepburn: long vowels are
+// not marked ("satou", "satoh" and "sato" are all "sato") and Kunrei-shiki syllables use Hepburn
+// spelling ("syuzi" is "shuji")
+func hepburnSpelling(word string) string {
+	word = strings.NewReplacer("sy", "sh", "ty", "ch", "zy", "j").Replace(word)
+	// Short words are more often Chinese or Korean syllables ("si", "tu") than Kunrei-shiki
+	if len(word) > 3 {
+		word = kunreiFu.ReplaceAllString(strings.NewReplacer("si", "shi", "ti", "chi", "tu", "tsu", "zi", "ji").Replace(word), "${1}fu")
+	}
+	word = collapseLongVowels(word)
+	// A trailing h or an h before a consonant marks a long o ("Ohno", "Satoh")
+	var sb strings.Builder
+	for i := 0; i < len(word); i++ {
+		if word[i] == 'h' && i > 0 && word[i-1] == 'o' && (i+1 == len(word) || !strings.ContainsRune("aeiouy", rune(word[i+1]))) {
+			continue
+		}
+		sb.WriteByte(word[i])
+	}
+	return sb.String()
+}
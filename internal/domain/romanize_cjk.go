@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// hanPinyin maps Han characters to their toneless pinyin, built from hanPinyinGroups
+var hanPinyin = func() map[rune]string {
+	readings := map[rune]string{}
+	for syllable, characters := range hanPinyinGroups {
+		for _, character := range characters {
+			readings[character] = syllable
+		}
+	}
+	return readings
+}()
+
+// kanaHepburn maps hiragana to their Hepburn romanization. Katakana are mapped to hiragana first.
+var kanaHepburn = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko", 'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so", 'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to", 'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho", 'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o", 'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+}
+
+// hangulInitials, hangulMedials and hangulFinals are the Revised Romanization of the jamo a
+// precomposed Hangul syllable is made of, in Unicode order
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+// koreanCompoundSurnames are the two-syllable Korean surnames
+var koreanCompoundSurnames = map[string]bool{
+	"남궁": true, "황보": true, "제갈": true, "선우": true, "독고": true, "사공": true, "서문": true,
+}
+
+// isCJK reports whether r is a Han, kana or Hangul letter, including the prolonged sound mark
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) || r == 'ー'
+}
+
+// isKana reports whether r is a hiragana or katakana letter
+func isKana(r rune) bool {
+	return unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// romanizeCJK romanizes a run of Han, kana or Hangul letters written without spaces and splits
+// it into surname and given name, which CJK names write family name first ("张伟" becomes
+// "zhang wei", "松田優希" becomes "matsuda yuki", "김민준" becomes "gim minjun"). Han characters
+// are read as Japanese when japanese is set or the run is or starts with a known Japanese name,
+// and as Chinese otherwise.
+func romanizeCJK(run []rune, japanese bool) string {
+	if unicode.Is(unicode.Hangul, run[0]) {
+		return romanizeHangul(run)
+	}
+	text := string(run)
+	if _, ok := japaneseGivenNameReadings[text]; ok && len(run) > 1 {
+		japanese = true
+	}
+	for surname := range japaneseSurnameReadings {
+		if len([]rune(surname)) > 1 && strings.HasPrefix(text, surname) {
+			japanese = true
+		}
+	}
+	if japanese || containsKana(run) {
+		return romanizeJapanese(run)
+	}
+	return romanizeChinese(run)
+}
+
+// romanizeChinese reads a Chinese name in pinyin, the surname apart from the given name
+func romanizeChinese(run []rune) string {
+	if len(run) == 1 {
+		return hanSyllables(run)
+	}
+	surname := run[:1]
+	if len(run) > 2 {
+		if _, ok := chineseCompoundSurnames[string(run[:2])]; ok {
+			surname = run[:2]
+		}
+	}
+	if reading, ok := chineseCompoundSurnames[string(surname)]; ok {
+		return reading + " " + hanSyllables(run[len(surname):])
+	}
+	return hanSyllables(surname) + " " + hanSyllables(run[len(surname):])
+}
+
+// hanSyllables joins the pinyin of Han characters, keeping characters without a known reading
+func hanSyllables(run []rune) string {
+	var sb strings.Builder
+	for _, r := range run {
+		if syllable, ok := hanPinyin[r]; ok {
+			sb.WriteString(syllable)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// romanizeJapanese reads a Japanese name in Hepburn. A known kanji surname at the start is
+// separated from the rest; kanji without a known name reading fall back to their pinyin.
+func romanizeJapanese(run []rune) string {
+	text := string(run)
+	surname := ""
+	for kanji := range japaneseSurnameReadings {
+		if strings.HasPrefix(text, kanji) && len(kanji) > len(surname) && len(kanji) < len(text) {
+			surname = kanji
+		}
+	}
+	if surname == "" {
+		return japaneseWord(run)
+	}
+	return japaneseSurnameReadings[surname] + " " + japaneseWord([]rune(strings.TrimPrefix(text, surname)))
+}
+
+// japaneseWord romanizes kanji and kana without splitting them
+func japaneseWord(run []rune) string {
+	if reading, ok := japaneseGivenNameReadings[string(run)]; ok {
+		return reading
+	}
+	if reading, ok := japaneseSurnameReadings[string(run)]; ok {
+		return reading
+	}
+	if containsKana(run) {
+		return romanizeKana(run)
+	}
+	return hanSyllables(run)
+}
+
+// romanizeKana romanizes hiragana and katakana in passport-style Hepburn: long vowels are not
+// marked ("さとう" becomes "sato") and the syllabic n is always written n
+func romanizeKana(run []rune) string {
+	var sb strings.Builder
+	geminate := false
+	for i := 0; i < len(run); i++ {
+		r := toHiragana(run[i])
+		switch r {
+		case 'ー':
+			continue
+		case 'っ':
+			geminate = true
+			continue
+		}
+		syllable, ok := kanaHepburn[r]
+		if !ok {
+			sb.WriteRune(run[i])
+			continue
+		}
+		if i+1 < len(run) {
+			switch next := toHiragana(run[i+1]); next {
+			case 'ゃ', 'ゅ', 'ょ':
+				// Contracted sounds: き + ゃ is kya, し + ゃ is sha
+				stem := strings.TrimSuffix(syllable, "i")
+				if strings.HasSuffix(stem, "sh") || strings.HasSuffix(stem, "ch") || strings.HasSuffix(stem, "j") {
+					syllable = stem + kanaHepburn[next][1:]
+				} else {
+					syllable = stem + kanaHepburn[next]
+				}
+				i++
+			case 'ぁ', 'ぃ', 'ぅ', 'ぇ', 'ぉ':
+				// Extended katakana for foreign sounds: フ + ァ is fa, ウ + ィ is wi
+				stem := syllable[:len(syllable)-1]
+				if stem == "" {
+					stem = "w"
+				}
+				syllable = stem + kanaHepburn[next]
+				i++
+			}
+		}
+		if geminate {
+			if strings.HasPrefix(syllable, "ch") {
+				sb.WriteByte('t')
+			} else if syllable != "" {
+				sb.WriteByte(syllable[0])
+			}
+			geminate = false
+		}
+		sb.WriteString(syllable)
+	}
+	return collapseLongVowels(sb.String())
+}
+
+// collapseLongVowels drops the second vowel of the long vowels ou, oo and uu, as passports do
+func collapseLongVowels(romaji string) string {
+	return strings.NewReplacer("ou", "o", "oo", "o", "uu", "u").Replace(romaji)
+}
+
+// toHiragana maps a katakana letter to the matching hiragana
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - 0x60
+	}
+	return r
+}
+
+// containsKana reports whether a run contains hiragana or katakana
+func containsKana(run []rune) bool {
+	for _, r := range run {
+		if isKana(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// romanizeHangul romanizes Hangul syllables with the Revised Romanization of Korean. A run of two
+// to four syllables is a full name: the surname is separated from the given name.
+func romanizeHangul(run []rune) string {
+	if len(run) < 2 || len(run) > 4 {
+		return hangulSyllables(run)
+	}
+	surname := 1
+	if len(run) > 2 && koreanCompoundSurnames[string(run[:2])] {
+		surname = 2
+	}
+	return hangulSyllables(run[:surname]) + " " + hangulSyllables(run[surname:])
+}
+
+// hangulSyllables joins the Revised Romanization of Hangul syllables, writing ㄹㄹ as ll
+func hangulSyllables(run []rune) string {
+	var sb strings.Builder
+	previousFinal := ""
+	for _, r := range run {
+		if r < 0xAC00 || r > 0xD7A3 {
+			sb.WriteRune(r)
+			previousFinal = ""
+			continue
+		}
+		index := int(r - 0xAC00)
+		initial := hangulInitials[index/(21*28)]
+		if initial == "r" && previousFinal == "l" {
+			initial = "l"
+		}
+		previousFinal = hangulFinals[index%28]
+		sb.WriteString(initial + hangulMedials[(index/28)%21] + previousFinal)
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "testing"
+
+func TestTransliterateCJK(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"张伟", "zhang wei"},
+		{"毛泽东", "mao zedong"},
+		{"欧阳娜娜", "ouyang nana"},
+		{"松田優希", "matsuda yuki"},
+		{"さとう ゆうき", "sato yuki"},
+		{"キッチン", "kitchin"},
+		{"김민준", "gim minjun"},
+		{"남궁민수", "namgung minsu"},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.name); got != tt.want {
+			t.Errorf("Transliterate('%s') = '%s', want '%s'", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCompareCJKNamesWithRomanizations(t *testing.T) {
+	pairs := [][2]string{
+		{"张伟", "Zhang Wei"},
+		{"张伟", "Wei Zhang"},
+		{"张伟", "Chang Wei"},
+		{"张伟", "Zhāng Wěi"},
+		{"张伟", "zhang1 wei3"},
+		{"毛泽东", "Mao Tse-tung"},
+		{"松田優希", "Yuki Matsuda"},
+		{"松田優希", "Yuuki Matsuda"},
+		{"さとう", "Satoh"},
+		{"김민준", "Kim Min-jun"},
+		{"박지성", "Park Ji-sung"},
+	}
+	for _, pair := range pairs {
+		result := CompareNamesDetailed(pair[0], pair[1])
+		if result.Score < 0.9 {
+			t.Errorf("'%s' vs '%s': expected a score of at least 0.90, got %.2f (%s, '%s' vs '%s')",
+				pair[0], pair[1], result.Score, result.Rule, result.Normalized1, result.Normalized2)
+		}
+	}
+}
+
+func TestRomanizationVariantsNeedCJKScript(t *testing.T) {
+	if !romanizationEquivalent("satoh", "sato") || !romanizationEquivalent("chang", "zhang") {
+		t.Error("expected romanization variants to be equivalent")
+	}
+	if romanizationEquivalent("zhang", "li") {
+		t.Error("expected different syllables not to be equivalent")
+	}
+
+	result := CompareNamesDetailed("John Moore", "John More")
+	if result.Rule == RuleRomanizationExact || (result.LastName != nil && result.LastName.RomanizationMatch) {
+		t.Errorf("expected Latin names not to be compared as romanizations, got %+v", result)
+	}
+}
@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ScoringConfig holds the weights and cut-offs used to score name, email and customer record matches.
+// Different product lines can load their own tolerances instead of relying on the defaults.
+type ScoringConfig struct {
+	// Similarity names the string similarity algorithm used to score token pairs (see SimilarityByName)
+	Similarity string `json:"similarity" yaml:"similarity"`
+	// PhoneticEncoders names the phonetic encoders consulted for every token pair (see PhoneticEncoderByName).
+	// Tokens agree phonetically when any of the encoders produces a shared key.
+	PhoneticEncoders []string `json:"phonetic_encoders" yaml:"phonetic_encoders"`
+	// PhoneticBoost is the similarity assigned to tokens that agree phonetically and pass LevenshteinCutoff
+	PhoneticBoost float64 `json:"phonetic_boost" yaml:"phonetic_boost"`
+	// LevenshteinCutoff is the minimum Levenshtein similarity for a phonetic match to count as exact
+	LevenshteinCutoff float64 `json:"levenshtein_cutoff" yaml:"levenshtein_cutoff"`
+	// NicknameWeight is the similarity assigned to given names the nickname dictionary declares
+	// variants of each other ("Bill" and "William"); zero disables nickname matching
+	NicknameWeight float64 `json:"nickname_weight" yaml:"nickname_weight"`
+	// InitialWeight is the similarity assigned to an initial and a name starting with that letter
+	// ("F." and "Ferney"); zero disables initial matching
+	InitialWeight float64 `json:"initial_weight" yaml:"initial_weight"`
+	// FirstNameWeight is the relative weight of first names in the positional score and the token alignment
+	FirstNameWeight float64 `json:"first_name_weight" yaml:"first_name_weight"`
+	// LastNameWeight is the relative weight of last names in the positional score and the token alignment
+	LastNameWeight float64 `json:"last_name_weight" yaml:"last_name_weight"`
+	// MiddleNameWeight is the relative weight of middle names in the token alignment
+	MiddleNameWeight float64 `json:"middle_name_weight" yaml:"middle_name_weight"`
+	// UnmatchedMiddlePenalty is the fraction of its weight a given or middle name without a partner
+	// in the other name counts as a mismatch in the token alignment
+	UnmatchedMiddlePenalty float64 `json:"unmatched_middle_penalty" yaml:"unmatched_middle_penalty"`
+	// UnmatchedSurnamePenalty is the fraction of its weight a surname without a partner in the other
+	// name counts as a mismatch in the token alignment
+	UnmatchedSurnamePenalty float64 `json:"unmatched_surname_penalty" yaml:"unmatched_surname_penalty"`
+	// OrderInvariant lets the comparison read the first name in the opposite order when that aligns
+	// its given name and surname with the second name ("Matsuda Yuki" and "Yuki Matsuda")
+	OrderInvariant bool `json:"order_invariant" yaml:"order_invariant"`
+	// MaternalSurnameDropWeight is the share of the paternal surname score kept when one name leaves
+	// out the maternal surname ("Brayan Perez" and "Brayan Perez Moreno")
+	MaternalSurnameDropWeight float64 `json:"maternal_surname_drop_weight" yaml:"maternal_surname_drop_weight"`
+	// SwappedSurnamesWeight is the share of the surname score kept when both surnames are written in
+	// opposite order ("Perez Moreno" and "Moreno Perez")
+	SwappedSurnamesWeight float64 `json:"swapped_surnames_weight" yaml:"swapped_surnames_weight"`
+	// PaternalSurnameDropWeight is the share of the surname score kept when one name only carries the
+	// maternal surname of the other ("Brayan Moreno" and "Brayan Perez Moreno")
+	PaternalSurnameDropWeight float64 `json:"paternal_surname_drop_weight" yaml:"paternal_surname_drop_weight"`
+	// SuffixMismatchPenalty is the fraction of the name score lost when both names carry different
+	// generational suffixes ("Jr." vs "Sr.")
+	SuffixMismatchPenalty float64 `json:"suffix_mismatch_penalty" yaml:"suffix_mismatch_penalty"`
+	// ChannelTypoCosts names, for every input channel, the substitution cost tables (see
+	// SubstitutionCostsByName) that price the typos of names captured through it: keyboard
+	// adjacency for typed names, glyph confusions for scanned documents. Names compared for a
+	// channel without tables use the plain similarity.
+	ChannelTypoCosts map[string][]string `json:"channel_typo_costs" yaml:"channel_typo_costs"`
+	// AcronymWeight is the similarity assigned to an organization name and its acronym ("IBM" and
+	// "International Business Machines"); zero disables acronym matching
+	AcronymWeight float64 `json:"acronym_weight" yaml:"acronym_weight"`
+	// LegalFormMismatchPenalty is the fraction of the organization score lost when both names carry
+	// legal forms of different kinds of entity ("LLC" vs "LP")
+	LegalFormMismatchPenalty float64 `json:"legal_form_mismatch_penalty" yaml:"legal_form_mismatch_penalty"`
+	// EmailLocalSimilarity names the similarity algorithm that scores the local parts of email addresses
+	EmailLocalSimilarity string `json:"email_local_similarity" yaml:"email_local_similarity"`
+	// EmailDomainTypoWeight is the domain score of two domains where one is a likely typo of the
+	// other, a popular provider ("gmial.com" and "gmail.com", see SuggestEmailDomain)
+	EmailDomainTypoWeight float64 `json:"email_domain_typo_weight" yaml:"email_domain_typo_weight"`
+	// EmailLocalWeight is the relative weight of the local parts in the email score
+	EmailLocalWeight float64 `json:"email_local_weight" yaml:"email_local_weight"`
+	// EmailDomainWeight is the relative weight of the domains in the email score
+	EmailDomainWeight float64 `json:"email_domain_weight" yaml:"email_domain_weight"`
+	// NameWeight is the share of the name score in the combined customer score
+	NameWeight float64 `json:"name_weight" yaml:"name_weight"`
+	// EmailWeight is the share of the email score in the combined customer score
+	EmailWeight float64 `json:"email_weight" yaml:"email_weight"`
+	// DateOfBirthMonthFirst reads numeric dates of birth whose order is ambiguous month first, as
+	// written in the US ("03/07/1985" is March 7), instead of day first
+	DateOfBirthMonthFirst bool `json:"date_of_birth_month_first" yaml:"date_of_birth_month_first"`
+	// DateOfBirthWeight is the share of the date of birth score in the combined customer score
+	DateOfBirthWeight float64 `json:"date_of_birth_weight" yaml:"date_of_birth_weight"`
+	// PhoneWeight is the share of the phone number score in the combined customer score
+	PhoneWeight float64 `json:"phone_weight" yaml:"phone_weight"`
+	// AddressWeight is the share of the postal address score in the combined customer score
+	AddressWeight float64 `json:"address_weight" yaml:"address_weight"`
+	// NationalIDWeight is the share of the national ID number score in the combined customer score
+	NationalIDWeight float64 `json:"national_id_weight" yaml:"national_id_weight"`
+}
+
+// DefaultScoringConfig returns the built-in weights and cut-offs. The package-level compare functions
+// use them, and a scoring config file only overrides the fields it sets.
+func DefaultScoringConfig() ScoringConfig {
+	return ScoringConfig{
+		Similarity:                SimilarityLevenshtein,
+		PhoneticEncoders:          []string{PhoneticMetaphone3},
+		PhoneticBoost:             0.9,
+		LevenshteinCutoff:         0.8,
+		NicknameWeight:            0.9,
+		InitialWeight:             0.85,
+		FirstNameWeight:           1.0,
+		LastNameWeight:            1.0,
+		MiddleNameWeight:          1.0,
+		UnmatchedMiddlePenalty:    0.05,
+		UnmatchedSurnamePenalty:   0.15,
+		OrderInvariant:            true,
+		MaternalSurnameDropWeight: 0.95,
+		SwappedSurnamesWeight:     0.9,
+		PaternalSurnameDropWeight: 0.75,
+		SuffixMismatchPenalty:     0.2,
+		ChannelTypoCosts: map[string][]string{
+			ChannelKeyboard:       {TypoCostsQWERTY},
+			ChannelKeyboardAZERTY: {TypoCostsAZERTY},
+			ChannelDocumentScan:   {TypoCostsOCR},
+		},
+		AcronymWeight:            0.9,
+		LegalFormMismatchPenalty: 0.1,
+		EmailLocalSimilarity:     SimilarityJaroWinkler,
+		EmailDomainTypoWeight:    0.9,
+		EmailLocalWeight:         0.7,
+		EmailDomainWeight:        0.3,
+		NameWeight:               0.5,
+		EmailWeight:              0.5,
+		DateOfBirthWeight:        0.5,
+		PhoneWeight:              0.4,
+		AddressWeight:            0.3,
+		NationalIDWeight:         0.8,
+	}
+}
+
+// Validate checks that the configuration values are usable
+func (c ScoringConfig) Validate() error {
+	weights := map[string]float64{
+		"phonetic_boost":               c.PhoneticBoost,
+		"nickname_weight":              c.NicknameWeight,
+		"initial_weight":               c.InitialWeight,
+		"first_name_weight":            c.FirstNameWeight,
+		"last_name_weight":             c.LastNameWeight,
+		"middle_name_weight":           c.MiddleNameWeight,
+		"maternal_surname_drop_weight": c.MaternalSurnameDropWeight,
+		"swapped_surnames_weight":      c.SwappedSurnamesWeight,
+		"paternal_surname_drop_weight": c.PaternalSurnameDropWeight,
+		"acronym_weight":               c.AcronymWeight,
+		"email_domain_typo_weight":     c.EmailDomainTypoWeight,
+		"email_local_weight":           c.EmailLocalWeight,
+		"email_domain_weight":          c.EmailDomainWeight,
+		"name_weight":                  c.NameWeight,
+		"email_weight":                 c.EmailWeight,
+		"date_of_birth_weight":         c.DateOfBirthWeight,
+		"phone_weight":                 c.PhoneWeight,
+		"address_weight":               c.AddressWeight,
+		"national_id_weight":           c.NationalIDWeight,
+	}
+	for _, name := range sortedKeys(weights) {
+		if weight := weights[name]; weight < 0 {
+			return fmt.Errorf("%s must not be negative, got %.2f", name, weight)
+		}
+	}
+	if _, err := SimilarityByName(c.Similarity); err != nil {
+		return err
+	}
+	if _, err := SimilarityByName(c.EmailLocalSimilarity); err != nil {
+		return err
+	}
+	for _, name := range c.PhoneticEncoders {
+		if _, err := PhoneticEncoderByName(name); err != nil {
+			return err
+		}
+	}
+	for channel, tables := range c.ChannelTypoCosts {
+		for _, name := range tables {
+			if _, err := SubstitutionCostsByName(name); err != nil {
+				return fmt.Errorf("channel %q: %w", channel, err)
+			}
+		}
+	}
+	if c.LevenshteinCutoff < 0 || c.LevenshteinCutoff > 1 {
+		return fmt.Errorf("levenshtein_cutoff must be between 0 and 1, got %.2f", c.LevenshteinCutoff)
+	}
+	if c.PhoneticBoost > 1 {
+		return fmt.Errorf("phonetic_boost must not exceed 1, got %.2f", c.PhoneticBoost)
+	}
+	if c.NicknameWeight > 1 {
+		return fmt.Errorf("nickname_weight must not exceed 1, got %.2f", c.NicknameWeight)
+	}
+	if c.InitialWeight > 1 {
+		return fmt.Errorf("initial_weight must not exceed 1, got %.2f", c.InitialWeight)
+	}
+	if c.EmailDomainTypoWeight > 1 {
+		return fmt.Errorf("email_domain_typo_weight must not exceed 1, got %.2f", c.EmailDomainTypoWeight)
+	}
+	if c.AcronymWeight > 1 {
+		return fmt.Errorf("acronym_weight must not exceed 1, got %.2f", c.AcronymWeight)
+	}
+	surnameWeights := map[string]float64{
+		"maternal_surname_drop_weight": c.MaternalSurnameDropWeight,
+		"swapped_surnames_weight":      c.SwappedSurnamesWeight,
+		"paternal_surname_drop_weight": c.PaternalSurnameDropWeight,
+	}
+	for _, name := range sortedKeys(surnameWeights) {
+		if weight := surnameWeights[name]; weight > 1 {
+			return fmt.Errorf("%s must not exceed 1, got %.2f", name, weight)
+		}
+	}
+	if c.UnmatchedMiddlePenalty < 0 || c.UnmatchedMiddlePenalty > 1 {
+		return fmt.Errorf("unmatched_middle_penalty must be between 0 and 1, got %.2f", c.UnmatchedMiddlePenalty)
+	}
+	if c.UnmatchedSurnamePenalty < 0 || c.UnmatchedSurnamePenalty > 1 {
+		return fmt.Errorf("unmatched_surname_penalty must be between 0 and 1, got %.2f", c.UnmatchedSurnamePenalty)
+	}
+	if c.SuffixMismatchPenalty < 0 || c.SuffixMismatchPenalty > 1 {
+		return fmt.Errorf("suffix_mismatch_penalty must be between 0 and 1, got %.2f", c.SuffixMismatchPenalty)
+	}
+	if c.LegalFormMismatchPenalty < 0 || c.LegalFormMismatchPenalty > 1 {
+		return fmt.Errorf("legal_form_mismatch_penalty must be between 0 and 1, got %.2f", c.LegalFormMismatchPenalty)
+	}
+	if c.FirstNameWeight+c.LastNameWeight == 0 {
+		return errors.New("first_name_weight and last_name_weight must not both be zero")
+	}
+	if c.EmailLocalWeight+c.EmailDomainWeight == 0 {
+		return errors.New("email_local_weight and email_domain_weight must not both be zero")
+	}
+	if c.NameWeight+c.EmailWeight == 0 {
+		return errors.New("name_weight and email_weight must not both be zero")
+	}
+	return nil
+}
+
+// CombineCustomerScores combines a name score and an email score using NameWeight and EmailWeight
+func (c ScoringConfig) CombineCustomerScores(nameScore, emailScore float64) float64 {
+	return (c.NameWeight*nameScore + c.EmailWeight*emailScore) / (c.NameWeight + c.EmailWeight)
+}
+
+// FieldWeight returns the share of a customer attribute in the combined customer score
+func (c ScoringConfig) FieldWeight(field CustomerField) float64 {
+	switch field {
+	case FieldName:
+		return c.NameWeight
+	case FieldEmail:
+		return c.EmailWeight
+	case FieldDateOfBirth:
+		return c.DateOfBirthWeight
+	case FieldPhone:
+		return c.PhoneWeight
+	case FieldAddress:
+		return c.AddressWeight
+	case FieldNationalID:
+		return c.NationalIDWeight
+	}
+	return 0
+}
+
+// sortedKeys returns the keys of weights in alphabetical order, so that Validate reports the same
+// field first on every run when several are invalid
+func sortedKeys(weights map[string]float64) []string {
+	keys := make([]string, 0, len(weights))
+	for key := range weights {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
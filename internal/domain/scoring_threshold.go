@@ -1,6 +1,7 @@
 package domain
 
-// IsMatch checks if the similarity score exceeds the threshold
+// IsMatch checks if the similarity score reaches the threshold. Name scores are always in [0,1],
+// so the same threshold means the same thing regardless of how many tokens the names have.
 func IsMatch(score float64, threshold float64) bool {
 	return score >= threshold
 }
@@ -0,0 +1,53 @@
+package domain
+
+import "fmt"
+
+// Similarity scores how alike two strings are, from 0.0 (nothing in common) to 1.0 (identical).
+// Implementations compare the strings as given; callers are expected to normalize them first.
+type Similarity interface {
+	Compare(a, b string) float64
+}
+
+// Names of the built-in string similarity algorithms, as used in ScoringConfig.Similarity
+const (
+	SimilarityLevenshtein         = "levenshtein"
+	SimilarityJaroWinkler         = "jaro-winkler"
+	SimilarityDamerauLevenshtein  = "damerau-levenshtein"
+	SimilarityOptimalStringAlign  = "osa"
+	SimilarityNGramJaccard        = "ngram-jaccard"
+	SimilarityNGramDice           = "ngram-dice"
+	SimilarityLongestCommonSubseq = "lcs"
+)
+
+// similarityAlgorithms maps algorithm names to their default implementations
+var similarityAlgorithms = map[string]Similarity{
+	SimilarityLevenshtein:         Levenshtein{},
+	SimilarityJaroWinkler:         JaroWinkler{PrefixScale: 0.1, BoostThreshold: 0.7},
+	SimilarityDamerauLevenshtein:  DamerauLevenshtein{},
+	SimilarityOptimalStringAlign:  OptimalStringAlignment{},
+	SimilarityNGramJaccard:        NGramJaccard{N: 2},
+	SimilarityNGramDice:           NGramDice{N: 2},
+	SimilarityLongestCommonSubseq: LCSRatio{},
+}
+
+// SimilarityByName returns the built-in similarity algorithm registered under name.
+// An empty name selects Levenshtein.
+func SimilarityByName(name string) (Similarity, error) {
+	if name == "" {
+		return Levenshtein{}, nil
+	}
+	similarity, ok := similarityAlgorithms[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown similarity algorithm %q", name)
+	}
+	return similarity, nil
+}
+
+// editSimilarity turns an edit distance into a similarity relative to the longer string
+func editSimilarity(distance, len1, len2 int) float64 {
+	maxLen := maxIntegers(len1, len2)
+	if maxLen == 0 {
+		return 1.0
+	}
+	return 1.0 - float64(distance)/float64(maxLen)
+}
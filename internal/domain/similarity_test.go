@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func assertSimilarity(t *testing.T, similarity Similarity, a, b string, want float64) {
+	t.Helper()
+	if got := similarity.Compare(a, b); math.Abs(got-want) > 0.001 {
+		t.Errorf("%T.Compare('%s', '%s') = %.4f, expected %.4f", similarity, a, b, got, want)
+	}
+}
+
+func TestJaroWinklerSimilarity(t *testing.T) {
+	jaroWinkler, _ := SimilarityByName(SimilarityJaroWinkler)
+	assertSimilarity(t, jaroWinkler, "martha", "marhta", 0.9611)
+	assertSimilarity(t, jaroWinkler, "dwayne", "duane", 0.84)
+	assertSimilarity(t, jaroWinkler, "dixon", "dicksonx", 0.8133)
+	assertSimilarity(t, jaroWinkler, "abc", "xyz", 0.0)
+	assertSimilarity(t, jaroWinkler, "", "", 1.0)
+
+	// Without a prefix scale Jaro-Winkler is the plain Jaro similarity
+	assertSimilarity(t, JaroWinkler{}, "martha", "marhta", 0.9444)
+}
+
+func TestTranspositionAwareSimilarities(t *testing.T) {
+	// "ca" -> "abc" is a transposition plus an insertion, which OSA may not combine
+	if got := damerauLevenshteinDistance([]rune("ca"), []rune("abc")); got != 2 {
+		t.Errorf("Expected Damerau-Levenshtein distance 2 for 'ca' -> 'abc', got %d", got)
+	}
+	if got := optimalStringAlignmentDistance([]rune("ca"), []rune("abc")); got != 3 {
+		t.Errorf("Expected OSA distance 3 for 'ca' -> 'abc', got %d", got)
+	}
+
+	assertSimilarity(t, DamerauLevenshtein{}, "jonh", "john", 0.75)
+	assertSimilarity(t, OptimalStringAlignment{}, "jonh", "john", 0.75)
+	assertSimilarity(t, Levenshtein{}, "jonh", "john", 0.5)
+}
+
+func TestNGramAndLCSSimilarities(t *testing.T) {
+	// Padded bigrams of "jon": _j jo on n_; of "john": _j jo oh hn n_
+	assertSimilarity(t, NGramJaccard{N: 2}, "jon", "john", 3.0/6.0)
+	assertSimilarity(t, NGramDice{N: 2}, "jon", "john", 6.0/9.0)
+	assertSimilarity(t, NGramDice{}, "ana", "ana", 1.0)
+	assertSimilarity(t, LCSRatio{}, "jon", "john", 0.75)
+	assertSimilarity(t, LCSRatio{}, "perez", "peres", 0.8)
+}
+
+func TestSimilarityByName(t *testing.T) {
+	if similarity, err := SimilarityByName(""); err != nil || similarity != (Levenshtein{}) {
+		t.Errorf("Expected an empty name to select Levenshtein, got %T, %v", similarity, err)
+	}
+	if _, err := SimilarityByName("soundex"); err == nil {
+		t.Errorf("Expected an error for an unknown similarity algorithm")
+	}
+}
+
+func TestCompareNamesWithJaroWinkler(t *testing.T) {
+	config := DefaultScoringConfig()
+	config.Similarity = SimilarityJaroWinkler
+	result := NewNameMatcher(config).Compare("Jonhathan", "Jonathan")
+
+	if result.FirstName == nil || result.FirstName.Algorithm != SimilarityJaroWinkler {
+		t.Fatalf("Expected the first name to be compared with %s, got %+v", SimilarityJaroWinkler, result.FirstName)
+	}
+	assertMatchWithLogging(t, result.Score, 0.9, "Jaro-Winkler match of 'Jonhathan' and 'Jonathan'")
+}
@@ -0,0 +1,61 @@
+package domain
+
+// Soundex is the American Soundex encoder: the first letter followed by three digits
+type Soundex struct{}
+
+// soundexCodes maps the letters A-Z to their Soundex digits; vowels, H, W and Y map to '0'
+const soundexCodes = "01230120022455012623010202"
+
+// Encode returns the Soundex key of name
+func (Soundex) Encode(name string) (string, string) {
+	letters := asciiLetters(name)
+	if len(letters) == 0 {
+		return "", ""
+	}
+
+	key := []byte{letters[0]}
+	last := soundexCodes[letters[0]-'A']
+	for _, letter := range letters[1:] {
+		code := soundexCodes[letter-'A']
+		if code != '0' && code != last {
+			key = append(key, code)
+		}
+		// H and W do not separate letters with the same code, vowels do
+		if letter != 'H' && letter != 'W' {
+			last = code
+		}
+		if len(key) == 4 {
+			break
+		}
+	}
+	for len(key) < 4 {
+		key = append(key, '0')
+	}
+	return string(key), ""
+}
+
+// RefinedSoundex is the refined Soundex encoder, which uses more letter groups than Soundex
+// and does not truncate the key
+type RefinedSoundex struct{}
+
+// refinedSoundexCodes maps the letters A-Z to their refined Soundex digits
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// Encode returns the refined Soundex key of name
+func (RefinedSoundex) Encode(name string) (string, string) {
+	letters := asciiLetters(name)
+	if len(letters) == 0 {
+		return "", ""
+	}
+
+	key := []byte{letters[0]}
+	var last byte
+	for _, letter := range letters {
+		code := refinedSoundexCodes[letter-'A']
+		if code != last {
+			key = append(key, code)
+		}
+		last = code
+	}
+	return string(key), ""
+}
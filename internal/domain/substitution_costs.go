@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Names of the built-in substitution cost tables, as used in ScoringConfig.ChannelTypoCosts
+const (
+	TypoCostsQWERTY = "qwerty"
+	TypoCostsAZERTY = "azerty"
+	TypoCostsOCR    = "ocr"
+)
+
+// Input channels with default typo cost tables in DefaultScoringConfig
+const (
+	// ChannelKeyboard is a name typed on a QWERTY keyboard
+	ChannelKeyboard = "keyboard"
+	// ChannelKeyboardAZERTY is a name typed on an AZERTY keyboard
+	ChannelKeyboardAZERTY = "keyboard-azerty"
+	// ChannelDocumentScan is a name read by character recognition from a scanned identity document
+	ChannelDocumentScan = "document-scan"
+)
+
+const (
+	// keyboardAdjacencyCost is the cost of typing a key next to the intended one
+	keyboardAdjacencyCost = 0.5
+	// ocrConfusionCost is the cost of a character recognition engine confusing similar glyphs
+	ocrConfusionCost = 0.3
+)
+
+// SubstitutionCost is the cost of writing From in place of To, or To in place of From
+type SubstitutionCost struct {
+	From string
+	To   string
+	Cost float64
+}
+
+// SubstitutionCosts is a symmetric table of substitution costs below 1, used by WeightedLevenshtein.
+// Entries may replace several runes at once ("rn" and "m"). A nil table charges every substitution 1.
+type SubstitutionCosts struct {
+	costs    map[string]map[string]float64
+	maxRunes int
+}
+
+// NewSubstitutionCosts creates a table from substitution costs. When a pair is listed more than
+// once, the lowest cost applies.
+func NewSubstitutionCosts(substitutions []SubstitutionCost) *SubstitutionCosts {
+	c := &SubstitutionCosts{costs: map[string]map[string]float64{}}
+	for _, s := range substitutions {
+		c.add(s.From, s.To, s.Cost)
+		c.add(s.To, s.From, s.Cost)
+	}
+	return c
+}
+
+// add records the cost of writing to in place of from
+func (c *SubstitutionCosts) add(from, to string, cost float64) {
+	if c.costs[from] == nil {
+		c.costs[from] = map[string]float64{}
+	}
+	if current, ok := c.costs[from][to]; !ok || cost < current {
+		c.costs[from][to] = cost
+	}
+	c.maxRunes = max(c.maxRunes, utf8.RuneCountInString(from), utf8.RuneCountInString(to))
+}
+
+// Merge returns a table holding the substitutions of c and other, at the lower cost of both
+func (c *SubstitutionCosts) Merge(other *SubstitutionCosts) *SubstitutionCosts {
+	merged := NewSubstitutionCosts(nil)
+	for _, table := range []*SubstitutionCosts{c, other} {
+		if table == nil {
+			continue
+		}
+		for from, costs := range table.costs {
+			for to, cost := range costs {
+				merged.add(from, to, cost)
+			}
+		}
+	}
+	return merged
+}
+
+// Cost returns the cost of writing b in place of a: 0 when they are equal, the cost in the table,
+// or 1 for any other substitution
+func (c *SubstitutionCosts) Cost(a, b string) float64 {
+	if a == b {
+		return 0.0
+	}
+	if c != nil {
+		if cost, ok := c.costs[a][b]; ok {
+			return cost
+		}
+	}
+	return 1.0
+}
+
+// longest returns the number of runes of the longest string in the table
+func (c *SubstitutionCosts) longest() int {
+	if c == nil {
+		return 1
+	}
+	return max(c.maxRunes, 1)
+}
+
+// RepairDigits replaces the digits written next to letters ("J0hn", "Wi1son") with the letter the
+// table confuses them with most, so names captured by character recognition keep their letters
+// through normalization. Digits without a letter substitute are left as they are.
+func (c *SubstitutionCosts) RepairDigits(name string) string {
+	runes := []rune(name)
+	repaired := false
+	for i, r := range runes {
+		if !unicode.IsDigit(r) || !(i > 0 && unicode.IsLetter(runes[i-1]) || i+1 < len(runes) && unicode.IsLetter(runes[i+1])) {
+			continue
+		}
+		if letter, ok := c.letterFor(r); ok {
+			runes[i], repaired = letter, true
+		}
+	}
+	if !repaired {
+		return name
+	}
+	return string(runes)
+}
+
+// letterFor returns the letter the table substitutes a digit with at the lowest cost
+func (c *SubstitutionCosts) letterFor(digit rune) (rune, bool) {
+	if c == nil {
+		return 0, false
+	}
+	best, bestCost := rune(0), 1.0
+	for to, cost := range c.costs[string(digit)] {
+		letter, size := utf8.DecodeRuneInString(to)
+		if size == len(to) && unicode.IsLetter(letter) && (cost < bestCost || cost == bestCost && letter < best) {
+			best, bestCost = letter, cost
+		}
+	}
+	return best, bestCost < 1
+}
+
+// substitutionCostTables maps table names to their builders
+var substitutionCostTables = map[string]func() *SubstitutionCosts{
+	TypoCostsQWERTY: func() *SubstitutionCosts {
+		return keyboardAdjacency([]string{"qwertyuiop", "asdfghjkl", "zxcvbnm"})
+	},
+	TypoCostsAZERTY: func() *SubstitutionCosts {
+		return keyboardAdjacency([]string{"azertyuiop", "qsdfghjklm", "wxcvbn"})
+	},
+	TypoCostsOCR: func() *SubstitutionCosts {
+		var substitutions []SubstitutionCost
+		for _, pair := range ocrConfusions {
+			substitutions = append(substitutions, SubstitutionCost{From: pair[0], To: pair[1], Cost: ocrConfusionCost})
+		}
+		return NewSubstitutionCosts(substitutions)
+	},
+}
+
+// ocrConfusions are the glyphs character recognition commonly reads as one another on scanned
+// documents. Every digit has a single letter, the one RepairDigits puts in its place.
+var ocrConfusions = [][2]string{
+	{"rn", "m"}, {"cl", "d"}, {"vv", "w"}, {"ii", "u"}, {"nn", "m"}, {"ri", "n"},
+	{"l", "i"}, {"l", "t"}, {"i", "j"}, {"e", "c"}, {"o", "c"}, {"u", "v"}, {"h", "b"}, {"n", "h"},
+	{"0", "o"}, {"1", "l"}, {"5", "s"}, {"8", "b"}, {"6", "g"}, {"2", "z"}, {"4", "a"},
+}
+
+// keyboardAdjacency returns the table of keys next to each other on a keyboard given by its rows
+// of letters, top to bottom. Rows are staggered, so a key touches the key above it and the one to
+// the right of that, and the key below it and the one to the left of that.
+func keyboardAdjacency(rows []string) *SubstitutionCosts {
+	var substitutions []SubstitutionCost
+	keys := make([][]rune, len(rows))
+	for i, row := range rows {
+		keys[i] = []rune(row)
+	}
+	for r, row := range keys {
+		for c, key := range row {
+			neighbours := []rune{}
+			if c+1 < len(row) {
+				neighbours = append(neighbours, row[c+1])
+			}
+			if r+1 < len(keys) {
+				for _, below := range []int{c - 1, c} {
+					if below >= 0 && below < len(keys[r+1]) {
+						neighbours = append(neighbours, keys[r+1][below])
+					}
+				}
+			}
+			for _, neighbour := range neighbours {
+				substitutions = append(substitutions, SubstitutionCost{From: string(key), To: string(neighbour), Cost: keyboardAdjacencyCost})
+			}
+		}
+	}
+	return NewSubstitutionCosts(substitutions)
+}
+
+// SubstitutionCostsByName returns the built-in substitution cost table registered under name
+func SubstitutionCostsByName(name string) (*SubstitutionCosts, error) {
+	build, ok := substitutionCostTables[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown typo cost table %q", name)
+	}
+	return build(), nil
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "strings"
+
+// surnameParticles are the prepositions and articles that open compound surnames ("da Silva",
+// "van der Berg", "de la Cruz", "bin Rashid", "Al-Saud"), in normalized form
+var surnameParticles = map[string]bool{
+	"da": true, "das": true, "do": true, "dos": true, "de": true, "del": true, "della": true, "dei": true,
+	"di": true, "du": true, "des": true, "la": true, "le": true, "lo": true, "van": true, "von": true,
+	"der": true, "den": true, "ter": true, "ten": true, "zu": true, "bin": true, "ibn": true, "bint": true,
+	"ben": true, "al": true, "el": true, "abu": true,
+}
+
+// surnameConjunction joins the paternal and maternal surnames in Spanish ("Ortega y Gasset")
+const surnameConjunction = "y"
+
+// ambiguousParticles double as given names ("Van Morrison", "Ben Stiller"), so they only count
+// as particles when they do not open the name
+var ambiguousParticles = map[string]bool{
+	"van": true, "ben": true, "del": true, "la": true, "le": true, "lo": true, "al": true, "el": true, "abu": true,
+}
+
+// surnamePrefixes are the patronymic prefixes written either apart from or joined to the rest of
+// the surname ("Mac Donald" and "MacDonald"). O' is already joined by the tokenizer ("O'Brien").
+var surnamePrefixes = map[string]bool{"mac": true, "mc": true}
+
+// isSurnameParticle reports whether a token is a surname particle; opening reports whether the
+// token opens the name
+func isSurnameParticle(token string, opening bool) bool {
+	return surnameParticles[token] && !(opening && ambiguousParticles[token])
+}
+
+// joinSurnamePrefixes joins a Mac or Mc written apart to the token that follows it, so both
+// spellings of the surname produce the same token. Prefixes before index from are left alone:
+// a prefix opening a name written given name first is more likely a given name ("Mac Miller").
+func joinSurnamePrefixes(tokens []string, from int) []string {
+	joined := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if i >= from && i+1 < len(tokens) && surnamePrefixes[tokens[i]] {
+			joined = append(joined, tokens[i]+tokens[i+1])
+			i++
+			continue
+		}
+		joined = append(joined, tokens[i])
+	}
+	return joined
+}
+
+// surnameStart returns the index of the first surname token of a name written given name first:
+// the last token together with the particles before it ("Juan de la Cruz" starts at "de") and
+// the surname it is joined to by y ("José Ortega y Gasset" starts at "Ortega"). It returns 0
+// when the name is only a compound surname ("da Silva").
+func surnameStart(tokens []string) int {
+	start := len(tokens) - 1
+	for start > 0 {
+		switch {
+		case isSurnameParticle(tokens[start-1], start-1 == 0):
+			start--
+		case tokens[start-1] == surnameConjunction && start >= 3:
+			start -= 2
+		default:
+			return start
+		}
+	}
+	return start
+}
+
+// surnameCore returns the surname without its particles and conjunctions, which are often dropped
+// or spelled differently ("da Silva" and "Silva", "van der Berg" and "van den Berg")
+func surnameCore(surname []string) string {
+	var core []string
+	for i, token := range surname {
+		if i == len(surname)-1 || !isOptionalSurnameToken(token) {
+			core = append(core, token)
+		}
+	}
+	return strings.Join(core, " ")
+}
+
+// isOptionalSurnameToken reports whether a surname token is a particle or conjunction that may be
+// left out when surnames are compared
+func isOptionalSurnameToken(token string) bool {
+	return surnameParticles[token] || token == surnameConjunction
+}
@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestCompareNamesWithSurnameParticles(t *testing.T) {
+	pairs := [][2]string{
+		{"José da Silva", "José Silva"},
+		{"Ludwig van Beethoven", "Ludwig Beethoven"},
+		{"Juan de la Cruz", "Juan Cruz"},
+		{"Ahmed bin Rashid", "Ahmed Rashid"},
+		{"Abdullah Al-Saud", "Abdullah Saud"},
+		{"Jan van der Berg", "Jan van den Berg"},
+		{"Ronald Mac Donald", "Ronald MacDonald"},
+		{"Ronald Mc Donald", "Ronald MacDonald"},
+	}
+	for _, pair := range pairs {
+		if result := CompareNamesDetailed(pair[0], pair[1]); result.Score != 1.0 {
+			t.Errorf("'%s' vs '%s': expected 1.00, got %.2f (%s)", pair[0], pair[1], result.Score, result.Rule)
+		}
+	}
+
+	if score := CompareNames("José da Silva", "da Silva"); score < 0.8 {
+		t.Errorf("Expected 'da Silva' to match 'José da Silva', got %.2f", score)
+	}
+	if score := CompareNames("José da Silva", "José Santos"); score >= 0.8 {
+		t.Errorf("Expected particles not to make different surnames match, got %.2f", score)
+	}
+}
+
+func TestSurnameCore(t *testing.T) {
+	tests := map[string]string{
+		"da silva":     "silva",
+		"van der berg": "berg",
+		"de la cruz":   "cruz",
+		"perez moreno": "perez moreno",
+		"de":           "de",
+	}
+	for surname, want := range tests {
+		if got := surnameCore(DefaultNameMatcher().Tokenize(surname)); got != want {
+			t.Errorf("surnameCore('%s') = '%s', want '%s'", surname, got, want)
+		}
+	}
+}
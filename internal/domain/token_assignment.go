@@ -0,0 +1,95 @@
+package domain
+
+import "math"
+
+// assignTokens finds the one-to-one assignment of rows to columns that maximizes the total score,
+// using the Hungarian (Kuhn-Munkres) algorithm. It returns the column assigned to every row, or -1
+// for the rows left over when there are more rows than columns.
+func assignTokens(scores [][]float64) []int {
+	rows := len(scores)
+	if rows == 0 || len(scores[0]) == 0 {
+		return filledInts(rows, -1)
+	}
+	cols := len(scores[0])
+	if rows > cols {
+		// The algorithm needs at least as many columns as rows, so solve the transposed problem
+		transposed := make([][]float64, cols)
+		for j := range transposed {
+			transposed[j] = make([]float64, rows)
+			for i := range scores {
+				transposed[j][i] = scores[i][j]
+			}
+		}
+		assignment := filledInts(rows, -1)
+		for j, i := range assignTokens(transposed) {
+			assignment[i] = j
+		}
+		return assignment
+	}
+
+	// Minimize the negated scores with row and column potentials u and v. Index 0 is a virtual
+	// column; match[j] is the row (1-based) assigned to column j.
+	u := make([]float64, rows+1)
+	v := make([]float64, cols+1)
+	match := make([]int, cols+1)
+	way := make([]int, cols+1)
+	for i := 1; i <= rows; i++ {
+		match[0] = i
+		column := 0
+		minValues := make([]float64, cols+1)
+		for j := range minValues {
+			minValues[j] = math.Inf(1)
+		}
+		used := make([]bool, cols+1)
+		for {
+			used[column] = true
+			row, delta, next := match[column], math.Inf(1), 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				if reduced := -scores[row-1][j-1] - u[row] - v[j]; reduced < minValues[j] {
+					minValues[j], way[j] = reduced, column
+				}
+				if minValues[j] < delta {
+					delta, next = minValues[j], j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minValues[j] -= delta
+				}
+			}
+			column = next
+			if match[column] == 0 {
+				break
+			}
+		}
+		// Flip the augmenting path
+		for column != 0 {
+			previous := way[column]
+			match[column] = match[previous]
+			column = previous
+		}
+	}
+
+	assignment := filledInts(rows, -1)
+	for j := 1; j <= cols; j++ {
+		if match[j] != 0 {
+			assignment[match[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
+
+// filledInts returns a slice of n copies of value
+func filledInts(n, value int) []int {
+	ints := make([]int, n)
+	for i := range ints {
+		ints[i] = value
+	}
+	return ints
+}
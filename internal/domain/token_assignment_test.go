@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAssignTokens(t *testing.T) {
+	tests := []struct {
+		scores [][]float64
+		want   []int
+	}{
+		// A greedy pairing would take the 0.9 and leave the second row with 0.2
+		{[][]float64{{0.9, 0.8}, {0.85, 0.2}}, []int{1, 0}},
+		{[][]float64{{1, 2, 3}, {2, 4, 6}, {3, 6, 9}}, []int{0, 1, 2}},
+		{[][]float64{{0.1, 0.7, 0.3}}, []int{1}},
+		{[][]float64{{1}, {5}, {2}}, []int{-1, 0, -1}},
+		{[][]float64{}, []int{}},
+	}
+	for _, tt := range tests {
+		if got := assignTokens(tt.scores); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("assignTokens(%v) = %v, want %v", tt.scores, got, tt.want)
+		}
+	}
+}
+
+func TestCompareNamesPairsTokensOnce(t *testing.T) {
+	for _, name := range []string{"John John", "John John John"} {
+		if score := CompareNames(name, "John Smith"); score > 0.5 {
+			t.Errorf("Expected a repeated 'john' not to match 'John Smith', got %.2f", score)
+		}
+	}
+
+	result := CompareNamesDetailed("Brayan F. Perez", "Brayan Ferney Perez Moreno")
+	if len(result.MiddleTokens) != 3 || !reflect.DeepEqual(result.Unmatched2, []string{"moreno"}) {
+		t.Errorf("Expected three aligned pairs and 'moreno' left over, got %+v", result)
+	}
+}
+
+func TestUnmatchedTokenPenalties(t *testing.T) {
+	missingMiddle := CompareNames("John Smith", "John Michael Peter Smith")
+	missingSurname := CompareNames("John Smith", "John Smith Jones")
+	if missingMiddle <= missingSurname {
+		t.Errorf("Expected a missing middle name (%.2f) to cost less than a missing surname (%.2f)", missingMiddle, missingSurname)
+	}
+
+	config := DefaultScoringConfig()
+	config.UnmatchedSurnamePenalty = 1.0
+	if strict := NewNameMatcher(config).Compare("John Smith", "John Smith Jones").Score; strict >= missingSurname {
+		t.Errorf("Expected a higher surname penalty to lower the score, got %.2f", strict)
+	}
+}
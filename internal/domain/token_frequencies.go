@@ -0,0 +1,62 @@
+package domain
+
+import "math"
+
+// minTokenFrequencyWeight keeps the most common tokens from dropping out of a comparison entirely
+const minTokenFrequencyWeight = 0.1
+
+// TokenFrequencies is an IDF-style model of how common name tokens are. A match on a common token
+// ("Smith", "Garcia") is weaker evidence than a match on a rare one ("Piedrahita"), so the matcher
+// scales the agreement of every token by its Weight. A TokenFrequencies is immutable and safe
+// for concurrent use.
+type TokenFrequencies struct {
+	counts map[string]int
+	total  int
+}
+
+// NewTokenFrequencies creates a model from the number of people carrying each name, as published
+// in census-style frequency files. Names are normalized like the names being compared.
+func NewTokenFrequencies(counts map[string]int) *TokenFrequencies {
+	f := &TokenFrequencies{counts: make(map[string]int, len(counts))}
+	for name, count := range counts {
+		if normalized := NormalizeName(name); normalized != "" && count > 0 {
+			f.counts[normalized] += count
+			f.total += count
+		}
+	}
+	return f
+}
+
+// LearnTokenFrequencies creates a model from a corpus of names, such as the customer base: the
+// frequency of a token is the number of names containing it
+func LearnTokenFrequencies(names []string) *TokenFrequencies {
+	f := &TokenFrequencies{counts: map[string]int{}, total: len(names)}
+	for _, name := range names {
+		seen := map[string]bool{}
+		for _, token := range TokenizeName(name) {
+			if !seen[token] {
+				seen[token] = true
+				f.counts[token]++
+			}
+		}
+	}
+	return f
+}
+
+// Weight returns the importance of a normalized token in (0,1]: its inverse document frequency
+// relative to that of a token never seen, which weighs 1. A nil model weighs every token 1.
+func (f *TokenFrequencies) Weight(token string) float64 {
+	if f == nil || f.total == 0 {
+		return 1.0
+	}
+	idf := math.Log(float64(f.total+1) / float64(f.counts[token]+1))
+	return max(idf/math.Log(float64(f.total+1)), minTokenFrequencyWeight)
+}
+
+// Len returns the number of distinct tokens in the model
+func (f *TokenFrequencies) Len() int {
+	if f == nil {
+		return 0
+	}
+	return len(f.counts)
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "testing"
+
+func censusFrequencies() *TokenFrequencies {
+	return NewTokenFrequencies(map[string]int{
+		"Smith": 2442977, "García": 1166120, "John": 4500000, "Maria": 2000000,
+		"Piedrahita": 40, "Xiomara": 300, "other": 90000000,
+	})
+}
+
+func TestTokenFrequencyWeights(t *testing.T) {
+	f := censusFrequencies()
+	if f.Len() != 7 {
+		t.Errorf("Expected 7 tokens, got %d", f.Len())
+	}
+	if f.Weight("garcia") >= f.Weight("piedrahita") || f.Weight("piedrahita") >= f.Weight("unseen") {
+		t.Errorf("Expected common tokens to weigh less than rare ones, got %.2f, %.2f and %.2f",
+			f.Weight("garcia"), f.Weight("piedrahita"), f.Weight("unseen"))
+	}
+	if w := f.Weight("other"); w != minTokenFrequencyWeight {
+		t.Errorf("Expected the most common token to weigh %.2f, got %.2f", minTokenFrequencyWeight, w)
+	}
+	var none *TokenFrequencies
+	if none.Weight("smith") != 1 || none.Len() != 0 {
+		t.Error("Expected a nil model to weigh every token 1")
+	}
+}
+
+func TestLearnTokenFrequencies(t *testing.T) {
+	f := LearnTokenFrequencies([]string{"John Smith", "John John Doe", "Jane Piedrahita"})
+	if f.Weight("john") >= f.Weight("smith") {
+		t.Errorf("Expected 'john' to weigh less than 'smith', got %.2f and %.2f", f.Weight("john"), f.Weight("smith"))
+	}
+	if w := f.Weight("john"); w <= 0 || w >= 1 {
+		t.Errorf("Expected a weight between 0 and 1 for 'john', got %.2f", w)
+	}
+}
+
+func TestCompareNamesWithTokenFrequencies(t *testing.T) {
+	weighted := NewNameMatcher(DefaultScoringConfig(), WithTokenFrequencies(censusFrequencies()))
+	unweighted := DefaultNameMatcher()
+
+	// A shared rare surname is stronger evidence, a shared common one weaker
+	rare1, rare2 := "Xiomara Piedrahita", "Maria Piedrahita"
+	common1, common2 := "Xiomara Smith", "Maria Smith"
+	rareDrop := unweighted.Compare(rare1, rare2).Score - weighted.Compare(rare1, rare2).Score
+	commonDrop := unweighted.Compare(common1, common2).Score - weighted.Compare(common1, common2).Score
+	if commonDrop <= 0 || rareDrop >= commonDrop {
+		t.Errorf("Expected a shared common surname to lower the score more than a shared rare one, got %.2f and %.2f", commonDrop, rareDrop)
+	}
+	if score := weighted.Compare("John Smith", "John Smith").Score; score != 1 {
+		t.Errorf("Expected identical names to score 1, got %.2f", score)
+	}
+}
+
+func TestTokenFrequenciesNeverRaiseMismatches(t *testing.T) {
+	weighted := NewNameMatcher(DefaultScoringConfig(), WithTokenFrequencies(censusFrequencies()))
+	unweighted := DefaultNameMatcher()
+
+	// A disagreement on a common token is still a disagreement
+	for _, pair := range [][2]string{
+		{"Xiomara Smith", "Xiomara Garcia"},
+		{"John Piedrahita", "Maria Piedrahita"},
+		{"John Smith", "Maria Smith"},
+		{"Xiomara Maria Piedrahita", "Xiomara Piedrahita"},
+	} {
+		with, without := weighted.Compare(pair[0], pair[1]).Score, unweighted.Compare(pair[0], pair[1]).Score
+		if with > without {
+			t.Errorf("Expected '%s' vs '%s' to score at most %.2f with frequencies, got %.2f", pair[0], pair[1], without, with)
+		}
+	}
+}
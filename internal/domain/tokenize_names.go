@@ -5,9 +5,17 @@ import (
 	"strings"
 )
 
+// nameSeparatorPattern matches the non-alphanumeric characters that separate name tokens
+var nameSeparatorPattern = regexp.MustCompile(`[^\w]`)
+
 // TokenizeName splits a name into tokens, replaces special characters with spaces, removes accents,
 // and ensures case-insensitive comparison.
 func TokenizeName(name string) []string {
+	return tokenizeName(name, nameSeparatorPattern)
+}
+
+// tokenizeName splits a name into tokens on the given separator pattern
+func tokenizeName(name string, separator *regexp.Regexp) []string {
 	// Replace hyphens and apostrophes with spaces
 	//name = strings.ReplaceAll(name, "-", " ")
 	name = strings.ReplaceAll(name, "'", "")
@@ -16,8 +24,7 @@ func TokenizeName(name string) []string {
 	name = NormalizeName(name)
 
 	// Replace all non-alphanumeric characters with spaces
-	re := regexp.MustCompile(`[^\w]`)
-	cleanedName := re.ReplaceAllString(name, " ")
+	cleanedName := separator.ReplaceAllString(name, " ")
 
 	// Split the cleaned name into tokens by spaces
 	tokens := strings.Fields(cleanedName)
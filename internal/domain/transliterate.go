@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// Transliterate romanizes Cyrillic, Greek, Arabic, Hebrew, Han, kana and Hangul letters so names
+// written in those scripts can be compared with their Latin spelling ("Иван Петров" becomes
+// "Ivan Petrov"). Other characters are returned unchanged.
+//
+//   - Cyrillic follows BGN/PCGN (Russian, with the Ukrainian, Belarusian and Serbian letters)
+//   - Greek follows ELOT 743, including the ου, αυ/ευ/ηυ, μπ, ντ and γγ/γκ digraphs
+//   - Arabic and Hebrew follow a simplified consonantal romanization: short vowels are only
+//     written when the vowel marks are present, so unvocalized names stay approximate
+//   - Han follows Hanyu Pinyin without tones, or Hepburn for known Japanese names and names
+//     containing kana; kana follow passport-style Hepburn and Hangul the Revised Romanization.
+//     CJK names written without spaces are split into surname and given name (see romanizeCJK).
+func Transliterate(name string) string {
+	runes := []rune(norm.NFC.String(name))
+	japanese := containsKana(runes)
+
+	var sb strings.Builder
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		var latin string
+		consumed := 1
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			latin = cyrillicLatin[unicode.ToLower(r)]
+		case unicode.Is(unicode.Greek, r):
+			latin, consumed = transliterateGreek(runes, i)
+		case unicode.Is(unicode.Arabic, r) || isArabicMark(r):
+			latin, consumed = transliterateArabic(runes, i)
+		case unicode.Is(unicode.Hebrew, r):
+			latin, consumed = transliterateHebrew(runes, i)
+		case isCJK(r):
+			// CJK names are written without spaces, so the romanization separates the words
+			for i+consumed < len(runes) && isCJK(runes[i+consumed]) {
+				consumed++
+			}
+			latin = romanizeCJK(runes[i:i+consumed], japanese)
+			if sb.Len() > 0 && !strings.HasSuffix(sb.String(), " ") {
+				latin = " " + latin
+			}
+			if i+consumed < len(runes) && !unicode.IsSpace(runes[i+consumed]) {
+				latin += " "
+			}
+		default:
+			sb.WriteRune(r)
+			i++
+			continue
+		}
+		if unicode.IsUpper(r) {
+			latin = capitalize(latin)
+		}
+		sb.WriteString(latin)
+		i += consumed
+	}
+	return sb.String()
+}
+
+// NameScript returns the name of the first non-Latin script found in a name, or "Latin" when
+// the name only uses Latin letters
+func NameScript(name string) string {
+	for _, r := range name {
+		if !unicode.IsLetter(r) || unicode.Is(unicode.Latin, r) {
+			continue
+		}
+		for script, table := range unicode.Scripts {
+			if script != "Latin" && unicode.Is(table, r) {
+				return script
+			}
+		}
+	}
+	return "Latin"
+}
+
+// cyrillicLatin maps lowercase Cyrillic letters to their BGN/PCGN romanization
+var cyrillicLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+	// Ukrainian and Belarusian
+	'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ў': "w",
+	// Serbian and Macedonian
+	'ђ': "dj", 'ј': "j", 'љ': "lj", 'њ': "nj", 'ћ': "c", 'џ': "dz", 'ѓ': "gj", 'ќ': "kj", 'ѕ': "dz",
+}
+
+// greekLatin maps unaccented lowercase Greek letters to their ELOT 743 romanization
+var greekLatin = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+// greekVoiceless are the letters before which αυ, ευ and ηυ are romanized with f instead of v
+var greekVoiceless = map[rune]bool{
+	'θ': true, 'κ': true, 'ξ': true, 'π': true, 'σ': true, 'ς': true, 'τ': true, 'φ': true, 'χ': true, 'ψ': true,
+}
+
+// transliterateGreek romanizes the Greek letter at runes[i], together with the next letter when
+// they form a digraph, and returns the romanization and the number of runes consumed
+func transliterateGreek(runes []rune, i int) (string, int) {
+	letter, next := greekBase(runes, i), greekBase(runes, i+1)
+	wordStart := i == 0 || !unicode.Is(unicode.Greek, runes[i-1])
+	nextHasDiaeresis := i+1 < len(runes) && strings.ContainsRune(norm.NFD.String(string(runes[i+1])), '̈')
+
+	switch {
+	case letter == 'ο' && next == 'υ' && !nextHasDiaeresis:
+		return "ou", 2
+	case (letter == 'α' || letter == 'ε' || letter == 'η') && next == 'υ' && !nextHasDiaeresis:
+		if after := greekBase(runes, i+2); after == 0 || greekVoiceless[after] {
+			return greekLatin[letter] + "f", 2
+		}
+		return greekLatin[letter] + "v", 2
+	case letter == 'μ' && next == 'π' && wordStart:
+		return "b", 2
+	case letter == 'ν' && next == 'τ' && wordStart:
+		return "d", 2
+	case letter == 'γ' && (next == 'γ' || next == 'ξ' || next == 'χ'):
+		return "n" + greekLatin[next], 2
+	}
+	return greekLatin[letter], 1
+}
+
+// greekBase returns the lowercase Greek letter at runes[i] without accents, or 0 when there is no
+// Greek letter at i
+func greekBase(runes []rune, i int) rune {
+	if i >= len(runes) || !unicode.Is(unicode.Greek, runes[i]) {
+		return 0
+	}
+	for _, r := range norm.NFD.String(string(unicode.ToLower(runes[i]))) {
+		return r
+	}
+	return 0
+}
+
+// arabicLatin maps Arabic and Persian letters to a simplified romanization. The context dependent
+// letters ع, و and ي are handled by transliterateArabic.
+var arabicLatin = map[rune]string{
+	'ا': "a", 'أ': "a", 'إ': "i", 'آ': "a", 'ٱ': "a", 'ب': "b", 'ت': "t", 'ث': "th",
+	'ج': "j", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "dh", 'ر': "r", 'ز': "z", 'س': "s",
+	'ش': "sh", 'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'غ': "gh", 'ف': "f", 'ق': "q",
+	'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h", 'ة': "a", 'ى': "a", 'ء': "", 'ئ': "",
+	'ؤ': "", 'ـ': "",
+	// Vowel marks, written only in vocalized text
+	'َ': "a", 'ُ': "u", 'ِ': "i",
+	// Persian and Urdu
+	'پ': "p", 'چ': "ch", 'ژ': "zh", 'گ': "g", 'ک': "k",
+}
+
+// transliterateArabic romanizes the Arabic letter at runes[i] and returns the romanization and
+// the number of runes consumed. Ayn is written as a at the start of a word ("Ali") and dropped
+// elsewhere; waw and yeh are consonants at the start of a word and the long
+// vowels u and i elsewhere.
+func transliterateArabic(runes []rune, i int) (string, int) {
+	letter := runes[i]
+	wordStart := i == 0 || !(unicode.Is(unicode.Arabic, runes[i-1]) || isArabicMark(runes[i-1]))
+	switch letter {
+	case 'ع':
+		if wordStart {
+			return "a", 1
+		}
+		return "", 1
+	case 'و':
+		if wordStart {
+			return "w", 1
+		}
+		return "u", 1
+	case 'ي', 'ی':
+		if wordStart {
+			return "y", 1
+		}
+		return "i", 1
+	}
+	return arabicLatin[letter], 1
+}
+
+// isArabicMark reports whether r is an Arabic vowel mark (harakat), which Unicode assigns to the
+// Inherited script rather than to Arabic
+func isArabicMark(r rune) bool {
+	return r >= 0x064B && r <= 0x065F
+}
+
+// hebrewLatin maps Hebrew letters to a simplified romanization. The letters whose sound depends
+// on a dagesh or shin dot, and the context dependent א, ו and י, are handled by transliterateHebrew.
+var hebrewLatin = map[rune]string{
+	'ג': "g", 'ד': "d", 'ה': "h", 'ז': "z", 'ח': "ch", 'ט': "t", 'ך': "ch", 'ל': "l",
+	'מ': "m", 'ם': "m", 'נ': "n", 'ן': "n", 'ס': "s", 'ע': "", 'ף': "f", 'צ': "ts",
+	'ץ': "ts", 'ק': "k", 'ר': "r", 'ת': "t",
+}
+
+// transliterateHebrew romanizes the Hebrew letter at runes[i], together with its points, and
+// returns the romanization and the number of runes consumed
+func transliterateHebrew(runes []rune, i int) (string, int) {
+	letter := runes[i]
+	consumed := 1
+	dagesh, sinDot := false, false
+	for i+consumed < len(runes) && unicode.Is(unicode.Mn, runes[i+consumed]) {
+		switch runes[i+consumed] {
+		case 'ּ':
+			dagesh = true
+		case 'ׂ':
+			sinDot = true
+		}
+		consumed++
+	}
+	wordStart := i == 0 || !unicode.Is(unicode.Hebrew, runes[i-1])
+	wordEnd := i+consumed >= len(runes) || !unicode.Is(unicode.Hebrew, runes[i+consumed])
+
+	switch letter {
+	case 'א':
+		if wordStart {
+			return "a", consumed
+		}
+		return "", consumed
+	case 'ב':
+		if dagesh || wordStart {
+			return "b", consumed
+		}
+		return "v", consumed
+	case 'ו':
+		if wordStart {
+			return "v", consumed
+		}
+		return "o", consumed
+	case 'י':
+		if wordStart {
+			return "y", consumed
+		}
+		return "i", consumed
+	case 'כ':
+		if dagesh || wordStart {
+			return "k", consumed
+		}
+		return "ch", consumed
+	case 'פ':
+		if dagesh || wordStart {
+			return "p", consumed
+		}
+		return "f", consumed
+	case 'ש':
+		if sinDot {
+			return "s", consumed
+		}
+		return "sh", consumed
+	case 'ה':
+		if wordEnd && !wordStart {
+			return "a", consumed
+		}
+	}
+	return hebrewLatin[letter], consumed
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	for i, r := range s {
+		return string(unicode.ToUpper(r)) + s[i+len(string(r)):]
+	}
+	return s
+}
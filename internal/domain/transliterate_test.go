@@ -0,0 +1,58 @@
+package domain
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"Иван Петров", "Ivan Petrov"},
+		{"Щукин Юрий", "Shchukin Yuriy"},
+		{"Олександр Шевченко", "Oleksandr Shevchenko"},
+		{"Γιώργος Παπαδόπουλος", "Giorgos Papadopoulos"},
+		{"Ευάγγελος Μπακογιάννης", "Evangelos Bakogiannis"},
+		{"Ντόκας Ευθύμιος", "Dokas Efthymios"},
+		{"علي", "ali"},
+		{"يوسف", "yusf"},
+		{"שָׂרָה", "sra"},
+		{"José Pérez", "José Pérez"},
+	}
+	for _, tt := range tests {
+		if got := Transliterate(tt.name); got != tt.want {
+			t.Errorf("Transliterate('%s') = '%s', want '%s'", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNameScript(t *testing.T) {
+	tests := map[string]string{
+		"Иван Петров":   "Cyrillic",
+		"Γιώργος":       "Greek",
+		"محمد":          "Arabic",
+		"דוד":           "Hebrew",
+		"Brayan Pérez":  "Latin",
+		"O'Conner-Ruiz": "Latin",
+	}
+	for name, want := range tests {
+		if got := NameScript(name); got != want {
+			t.Errorf("NameScript('%s') = %s, want %s", name, got, want)
+		}
+	}
+}
+
+func TestCompareNamesAcrossScripts(t *testing.T) {
+	pairs := [][2]string{
+		{"Иван Петров", "Ivan Petrov"},
+		{"Γιώργος Παπαδόπουλος", "Giorgos Papadopoulos"},
+		{"Александр Сергеевич Пушкин", "Aleksandr Pushkin"},
+	}
+	for _, pair := range pairs {
+		result := CompareNamesDetailed(pair[0], pair[1])
+		if result.Score < 0.9 {
+			t.Errorf("'%s' vs '%s': expected a score of at least 0.90, got %.2f", pair[0], pair[1], result.Score)
+		}
+		if result.Name1 != pair[0] || result.Script1 == "Latin" || result.Script2 != "Latin" {
+			t.Errorf("'%s' vs '%s': expected the original names and scripts in the result, got %+v", pair[0], pair[1], result)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package domain
+
+// weightedLevenshteinAlgorithm is the algorithm name reported for token comparisons scored by WeightedLevenshtein
+const weightedLevenshteinAlgorithm = "weighted-levenshtein"
+
+// WeightedLevenshtein is the edit-distance similarity that charges substitutions by a table of
+// costs, so likely typos ("Jphn" on a QWERTY keyboard) and scanning errors ("rn" read as "m") cost
+// less than arbitrary edits. Substitutions not in the table, insertions and deletions cost 1.
+type WeightedLevenshtein struct {
+	Costs *SubstitutionCosts
+}
+
+// Compare returns the weighted Levenshtein similarity of a and b, relative to the longer string
+func (w WeightedLevenshtein) Compare(a, b string) float64 {
+	r1, r2 := []rune(a), []rune(b)
+	maxLen := max(len(r1), len(r2))
+	if maxLen == 0 {
+		return 1.0
+	}
+	return max(0.0, 1.0-weightedLevenshteinDistance(r1, r2, w.Costs)/float64(maxLen))
+}
+
+// weightedLevenshteinDistance computes the Levenshtein distance of r1 and r2 with substitution
+// costs from costs, which may replace several runes at once ("rn" and "m")
+func weightedLevenshteinDistance(r1, r2 []rune, costs *SubstitutionCosts) float64 {
+	d := make([][]float64, len(r1)+1)
+	for i := range d {
+		d[i] = make([]float64, len(r2)+1)
+		d[i][0] = float64(i)
+	}
+	for j := range d[0] {
+		d[0][j] = float64(j)
+	}
+
+	longest := costs.longest()
+	for i := 1; i <= len(r1); i++ {
+		for j := 1; j <= len(r2); j++ {
+			substitution := 0.0
+			if r1[i-1] != r2[j-1] {
+				substitution = costs.Cost(string(r1[i-1]), string(r2[j-1]))
+			}
+			d[i][j] = min(
+				d[i-1][j-1]+substitution, // substitution
+				d[i][j-1]+1,              // insertion
+				d[i-1][j]+1,              // deletion
+			)
+			// substitutions of several runes end at i and j
+			for n1 := 1; n1 <= min(longest, i); n1++ {
+				for n2 := 1; n2 <= min(longest, j); n2++ {
+					if n1 == 1 && n2 == 1 {
+						continue
+					}
+					if cost := costs.Cost(string(r1[i-n1:i]), string(r2[j-n2:j])); cost < 1 {
+						d[i][j] = min(d[i][j], d[i-n1][j-n2]+cost)
+					}
+				}
+			}
+		}
+	}
+	return d[len(r1)][len(r2)]
+}
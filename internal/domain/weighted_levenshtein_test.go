@@ -0,0 +1,84 @@
+package domain
+
+import "testing"
+
+func typoCosts(t *testing.T, name string) *SubstitutionCosts {
+	t.Helper()
+	costs, err := SubstitutionCostsByName(name)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return costs
+}
+
+func TestWeightedLevenshteinKeyboardAdjacency(t *testing.T) {
+	qwerty := WeightedLevenshtein{Costs: typoCosts(t, TypoCostsQWERTY)}
+	assertSimilarity(t, qwerty, "john", "jphn", 0.875)
+	assertSimilarity(t, qwerty, "john", "jxhn", 0.75)
+	assertSimilarity(t, qwerty, "john", "john", 1.0)
+
+	// "q" and "a" swap places on AZERTY keyboards
+	azerty := WeightedLevenshtein{Costs: typoCosts(t, TypoCostsAZERTY)}
+	assertSimilarity(t, azerty, "alvarez", "qlvarez", 1-0.5/7)
+	assertSimilarity(t, WeightedLevenshtein{}, "alvarez", "qlvarez", 1-1.0/7)
+}
+
+func TestWeightedLevenshteinOCRConfusions(t *testing.T) {
+	ocr := WeightedLevenshtein{Costs: typoCosts(t, TypoCostsOCR)}
+	assertSimilarity(t, ocr, "smith", "srnith", 1-0.3/6)
+	assertSimilarity(t, ocr, "madison", "maclison", 1-0.3/8)
+	assertSimilarity(t, ocr, "clark", "ciark", 1-0.3/5)
+}
+
+func TestRepairDigits(t *testing.T) {
+	ocr := typoCosts(t, TypoCostsOCR)
+	tests := []struct{ name, want string }{
+		{"J0hn Smith", "John Smith"},
+		{"Wi1son", "Wilson"},
+		{"8arbara 5mith", "barbara smith"},
+		{"John Smith 3rd", "John Smith 3rd"},
+		{"Unit 42", "Unit 42"},
+	}
+	for _, tt := range tests {
+		if got := ocr.RepairDigits(tt.name); got != tt.want {
+			t.Errorf("RepairDigits('%s') = '%s', want '%s'", tt.name, got, tt.want)
+		}
+	}
+	if got := typoCosts(t, TypoCostsQWERTY).RepairDigits("J0hn"); got != "J0hn" {
+		t.Errorf("Expected keyboard tables to leave digits alone, got '%s'", got)
+	}
+}
+
+func TestCompareNamesWithChannelTypoCosts(t *testing.T) {
+	matcher := DefaultNameMatcher()
+	tests := []struct {
+		name1, name2, channel string
+	}{
+		{"Jphn Smith", "John Smith", ChannelKeyboard},
+		{"J0hn Srnith", "John Smith", ChannelDocumentScan},
+		{"Wi1liam Ciark", "William Clark", ChannelDocumentScan},
+	}
+	for _, tt := range tests {
+		plain := matcher.Compare(tt.name1, tt.name2)
+		weighted := matcher.CompareWithOptions(tt.name1, tt.name2, CompareOptions{Channel: tt.channel})
+		if weighted.Score <= plain.Score || weighted.Score < 0.9 {
+			t.Errorf("'%s' vs '%s' from %s: expected the typo costs to raise %.2f to at least 0.90, got %.2f",
+				tt.name1, tt.name2, tt.channel, plain.Score, weighted.Score)
+		}
+	}
+
+	// A key far from the intended one is still a full edit
+	plain := matcher.Compare("Jxhn Smith", "John Smith")
+	weighted := matcher.CompareWithOptions("Jxhn Smith", "John Smith", CompareOptions{Channel: ChannelKeyboard})
+	if weighted.Score != plain.Score {
+		t.Errorf("Expected a non-adjacent key to cost a full edit, got %.2f instead of %.2f", weighted.Score, plain.Score)
+	}
+}
+
+func TestValidateRejectsUnknownTypoCostTables(t *testing.T) {
+	config := DefaultScoringConfig()
+	config.ChannelTypoCosts = map[string][]string{"kiosk": {"dvorak"}}
+	if err := config.Validate(); err == nil {
+		t.Error("Expected an error for an unknown typo cost table")
+	}
+}
@@ -6,4 +6,7 @@ import "net/http"
 type HTTPHandler interface {
 	NameMatchHandler(w http.ResponseWriter, r *http.Request)
 	EmailMatchHandler(w http.ResponseWriter, r *http.Request)
+	OrganizationMatchHandler(w http.ResponseWriter, r *http.Request)
+	NameEmailMatchHandler(w http.ResponseWriter, r *http.Request)
+	CustomerMatchHandler(w http.ResponseWriter, r *http.Request)
 }